@@ -1,8 +1,7 @@
-package main
+package day01
 
 import (
 	_ "embed"
-	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
@@ -14,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 1, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -31,8 +24,8 @@ func main() {
 
 type solver struct{}
 
-func (ps *solver) SolvePart1(input string) string {
-	fmt.Println("\tSolving problem")
+func (ps *solver) SolvePart1(input string) common.Answer {
+	common.Debugf("Solving problem")
 	lines := strings.Split(string(input), "\n")
 	sum := 0
 
@@ -62,11 +55,11 @@ func (ps *solver) SolvePart1(input string) string {
 		sum += val
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
 
-func (ps *solver) SolvePart2(input string) string {
-	fmt.Println("\tSolving problem")
+func (ps *solver) SolvePart2(input string) common.Answer {
+	common.Debugf("Solving problem")
 	lines := strings.Split(string(input), "\n")
 	sum := 0
 
@@ -132,5 +125,5 @@ func (ps *solver) SolvePart2(input string) string {
 		sum += val
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
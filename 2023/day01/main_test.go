@@ -1,4 +1,4 @@
-package main
+package day01
 
 import (
 	_ "embed"
@@ -33,3 +33,22 @@ func Test_y2023d01(t *testing.T) {
 		},
 	)
 }
+
+func Benchmark_y2023d01(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
@@ -1,10 +1,9 @@
-package main
+package day02
 
 import (
 	_ "embed"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -14,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 2, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -34,7 +27,7 @@ type solver struct{}
 // Determine which games would have been possible if the bag had been loaded
 // with only 12 red cubes, 13 green cubes, and 14 blue cubes. What is the sum
 // of the IDs of those games?
-func (ps *solver) SolvePart1(input string) string {
+func (ps *solver) SolvePart1(input string) common.Answer {
 	games := parseInput(input)
 	sum := 0
 
@@ -44,12 +37,12 @@ func (ps *solver) SolvePart1(input string) string {
 		}
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
 
 // For each game, find the minimum set of cubes that must have been present.
 // What is the sum of the power of these sets?
-func (ps *solver) SolvePart2(input string) string {
+func (ps *solver) SolvePart2(input string) common.Answer {
 	games := parseInput(input)
 	sum := 0
 
@@ -57,7 +50,7 @@ func (ps *solver) SolvePart2(input string) string {
 		sum += game.MinPower()
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
 
 // Input parser
@@ -121,6 +114,8 @@ func (g *Game) MinPower() int {
 }
 
 func parseInput(input string) []Game {
+	defer common.TimeParsing()()
+
 	result := []Game{}
 
 	lines := strings.Split(string(input), "\n")
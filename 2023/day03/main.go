@@ -1,9 +1,8 @@
-package main
+package day03
 
 import (
 	_ "embed"
 	"fmt"
-	"strconv"
 	"strings"
 	"unicode"
 
@@ -14,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 3, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -31,7 +24,7 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	grid := s.parseInput(input)
 	result := 0
 
@@ -72,10 +65,10 @@ func (s *solver) SolvePart1(input string) string {
 		}
 	}
 
-	return strconv.Itoa(result)
+	return common.IntAnswer(result)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	grid := s.parseInput(input)
 
 	isOnNumber := false
@@ -133,7 +126,7 @@ func (s *solver) SolvePart2(input string) string {
 		}
 	}
 
-	return strconv.Itoa(result)
+	return common.IntAnswer(result)
 }
 
 //--------------------------------------------------------------------
@@ -1,10 +1,9 @@
-package main
+package day04
 
 import (
 	_ "embed"
 	"math"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -14,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 4, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -31,7 +24,7 @@ func main() {
 
 type solver struct{}
 
-func (ps *solver) SolvePart1(input string) string {
+func (ps *solver) SolvePart1(input string) common.Answer {
 	cards := parseInput(input)
 	sum := 0
 
@@ -43,10 +36,10 @@ func (ps *solver) SolvePart1(input string) string {
 		sum += int(math.Pow(2, float64(len(intersection)-1)))
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
 
-func (ps *solver) SolvePart2(input string) string {
+func (ps *solver) SolvePart2(input string) common.Answer {
 	cards := parseInput(input)
 	numCardssMap := map[int]int{}
 
@@ -73,7 +66,7 @@ func (ps *solver) SolvePart2(input string) string {
 		totalCards += quantity
 	}
 
-	return strconv.Itoa(totalCards)
+	return common.IntAnswer(totalCards)
 }
 
 // Parsing code
@@ -87,6 +80,8 @@ type Card struct {
 }
 
 func parseInput(input string) []Card {
+	defer common.TimeParsing()()
+
 	cards := []Card{}
 
 	lines := strings.Split(string(input), "\n")
@@ -1,4 +1,4 @@
-package main
+package day04
 
 import (
 	_ "embed"
@@ -36,3 +36,22 @@ func Test_y2023d04(t *testing.T) {
 		},
 	)
 }
+
+func Benchmark_y2023d04(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
@@ -1,8 +1,9 @@
-package main
+package day05
 
 import (
+	"context"
 	_ "embed"
-	"fmt"
+	"math"
 	"runtime"
 	"slices"
 	"strings"
@@ -15,18 +16,13 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
 
 	// use all cores
 	runtime.GOMAXPROCS(runtime.NumCPU())
-}
 
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 5, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -35,7 +31,7 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	seedInput := ParseSeedInput(input)
 
 	var locations []int
@@ -45,20 +41,36 @@ func (s *solver) SolvePart1(input string) string {
 
 	minLocation := slices.Min(locations)
 
-	return fmt.Sprintf("%d", minLocation)
+	return common.IntAnswer(minLocation)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
+	return s.SolvePart2Context(context.Background(), input)
+}
+
+func (s *solver) SolvePart1Context(ctx context.Context, input string) common.Answer {
+	return s.SolvePart1(input)
+}
+
+// SolvePart2Context stops the brute force once ctx is done, it takes minutes
+// on the real input.
+func (s *solver) SolvePart2Context(ctx context.Context, input string) common.Answer {
 	seedInput := ParseSeedInput(input)
 	var wg sync.WaitGroup
 
 	processSeed := func(seed int, rngLen int, ch chan<- int) {
 		defer wg.Done()
-		var locations []int
+		// keep only the lowest location, the ranges hold billions of seeds
+		minLocation := math.MaxInt
 		for i := 0; i < rngLen; i++ {
-			locations = append(locations, seedInput.GetLocation(seed+i))
+			// checking every seed would slow the loop down
+			if i%(1<<16) == 0 && ctx.Err() != nil {
+				return
+			}
+			if location := seedInput.GetLocation(seed + i); location < minLocation {
+				minLocation = location
+			}
 		}
-		minLocation := slices.Min(locations)
 		ch <- minLocation
 	}
 
@@ -74,6 +86,9 @@ func (s *solver) SolvePart2(input string) string {
 
 	wg.Wait()
 	close(valueChan)
+	if ctx.Err() != nil {
+		return common.Answer{}
+	}
 
 	locations := []int{}
 	for result := range valueChan {
@@ -82,7 +97,7 @@ func (s *solver) SolvePart2(input string) string {
 
 	minLocation := slices.Min(locations)
 
-	return fmt.Sprintf("%d", minLocation)
+	return common.IntAnswer(minLocation)
 }
 
 //--------------------------------------------------------------------
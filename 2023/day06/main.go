@@ -1,4 +1,4 @@
-package main
+package day06
 
 import (
 	_ "embed"
@@ -13,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 6, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,21 +24,21 @@ func main() {
 
 type solver struct{}
 
-func (ps *solver) SolvePart1(input string) string {
+func (ps *solver) SolvePart1(input string) common.Answer {
 	result := int64(1)
 	races := ps.parseInput(input, false)
 	for _, race := range races {
 		result *= race.CalculateNumberOfWaysToWin()
 	}
 
-	return fmt.Sprintf("%d", result)
+	return common.Int64Answer(result)
 }
 
-func (ps *solver) SolvePart2(input string) string {
+func (ps *solver) SolvePart2(input string) common.Answer {
 	race := ps.parseInput(input, true)[0]
 	result := race.CalculateNumberOfWaysToWin()
 
-	return fmt.Sprintf("%d", result)
+	return common.Int64Answer(result)
 }
 
 //--------------------------------------------------------------------
@@ -1,8 +1,7 @@
-package main
+package day07
 
 import (
 	_ "embed"
-	"fmt"
 	"sort"
 	"strings"
 
@@ -13,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 7, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,7 +23,7 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	camelCardInput := parseCamelCardInput(input, false)
 
 	// Sort hands
@@ -43,10 +36,10 @@ func (s *solver) SolvePart1(input string) string {
 		result += hand.Bid * float64(rank+1)
 	}
 
-	return fmt.Sprintf("%.0f", result)
+	return common.IntAnswer(int(result))
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	camelCardInput := parseCamelCardInput(input, true)
 
 	// Sort hands
@@ -59,7 +52,7 @@ func (s *solver) SolvePart2(input string) string {
 		result += hand.Bid * float64(rank+1)
 	}
 
-	return fmt.Sprintf("%.0f", result)
+	return common.IntAnswer(int(result))
 }
 
 //--------------------------------------------------------------------
@@ -118,6 +111,8 @@ type CamelCardInput struct {
 }
 
 func parseCamelCardInput(input string, enableJokers bool) CamelCardInput {
+	defer common.TimeParsing()()
+
 	result := CamelCardInput{}
 
 	lines := strings.Split(string(input), "\n")
@@ -1,9 +1,8 @@
-package main
+package day08
 
 import (
 	_ "embed"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2023, 8, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,7 +23,7 @@ func main() {
 
 type solver struct{}
 
-func (ps *solver) SolvePart1(input string) string {
+func (ps *solver) SolvePart1(input string) common.Answer {
 	data := parseInput(input)
 
 	current := "AAA"
@@ -50,10 +43,10 @@ func (ps *solver) SolvePart1(input string) string {
 	}
 Escape:
 
-	return strconv.Itoa(numSteps)
+	return common.IntAnswer(numSteps)
 }
 
-func (ps *solver) SolvePart2(input string) string {
+func (ps *solver) SolvePart2(input string) common.Answer {
 	data := parseInput(input)
 
 	answers := make([]int, 0)
@@ -65,7 +58,7 @@ func (ps *solver) SolvePart2(input string) string {
 	}
 
 	answer := common.LCM(answers[0], answers[1], answers[2:]...)
-	return strconv.Itoa(answer)
+	return common.IntAnswer(answer)
 }
 
 //--------------------------------------------------------------------
@@ -124,6 +117,8 @@ type Input struct {
 }
 
 func parseInput(input string) Input {
+	defer common.TimeParsing()()
+
 	result := Input{
 		nodeToNeighbors: make(map[string]Node),
 	}
@@ -1,4 +1,4 @@
-package main
+package day08
 
 import (
 	_ "embed"
@@ -37,3 +37,22 @@ func Test_y2023d08(t *testing.T) {
 		},
 	)
 }
+
+func Benchmark_y2023d08(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
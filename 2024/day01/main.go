@@ -1,4 +1,4 @@
-package main
+package day01
 
 import (
 	_ "embed"
@@ -13,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 1, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,7 +24,7 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 
 	// sort the lists
@@ -48,10 +42,10 @@ func (s *solver) SolvePart1(input string) string {
 		diff += common.AbsInt(left - right)
 	}
 
-	return strconv.Itoa(diff)
+	return common.IntAnswer(diff)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	// panic("not implemented")
 	occurenceCountMap := make(map[int]int)
 	for _, num := range parseInput(input).RightList {
@@ -68,7 +62,7 @@ func (s *solver) SolvePart2(input string) string {
 		similarityScore += count * num
 	}
 
-	return strconv.Itoa(similarityScore)
+	return common.IntAnswer(similarityScore)
 }
 
 type ProblemInput struct {
@@ -77,6 +71,8 @@ type ProblemInput struct {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := strings.Split(strings.TrimSpace(input), "\n")
 	leftList := []int{}
 	rightList := []int{}
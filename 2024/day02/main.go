@@ -1,4 +1,4 @@
-package main
+package day02
 
 import (
 	_ "embed"
@@ -12,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 2, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,16 +23,16 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	safeCount := problemInput.countSafe(false)
-	return strconv.Itoa(safeCount)
+	return common.IntAnswer(safeCount)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	safeCount := problemInput.countSafe(true)
-	return strconv.Itoa(safeCount)
+	return common.IntAnswer(safeCount)
 }
 
 type ProblemInput struct {
@@ -46,6 +40,8 @@ type ProblemInput struct {
 }
 
 func parseInput(input string) ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := strings.Split(strings.TrimSpace(input), "\n")
 
 	result := ProblemInput{
@@ -1,4 +1,4 @@
-package main
+package day03
 
 import (
 	_ "embed"
@@ -13,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 3, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,7 +24,7 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	sum := 0
 
 	// each line contains many mul instructions
@@ -54,10 +48,10 @@ func (s *solver) SolvePart1(input string) string {
 		sum += x * y
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 
 	mulRe := regexp.MustCompile(`mul\((\d{1,3}),(\d{1,3})\)`)
 	// do() regex
@@ -118,5 +112,5 @@ func (s *solver) SolvePart2(input string) string {
 		}
 	}
 
-	return strconv.Itoa(sum)
+	return common.IntAnswer(sum)
 }
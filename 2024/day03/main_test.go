@@ -1,4 +1,4 @@
-package main
+package day03
 
 import (
 	_ "embed"
@@ -10,8 +10,8 @@ import (
 //go:embed example_input.txt
 var exampleInput string
 
-//go:embed example_input_part2.txt
-var exampleInputPart2 string
+//go:embed example_part2_input.txt
+var examplePart2Input string
 
 func Test_y2024d03(t *testing.T) {
 	common.RunTests(
@@ -26,10 +26,29 @@ func Test_y2024d03(t *testing.T) {
 			},
 			{
 				Name:  "provided-example",
-				Input: exampleInputPart2,
+				Input: examplePart2Input,
 				Part:  2,
 				Want:  "48",
 			},
 		},
 	)
 }
+
+func Benchmark_y2024d03(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
@@ -1,8 +1,7 @@
-package main
+package day04
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 4, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,38 +22,30 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 
 	wordCount := 0
-	for r, row := range problemInput.WordSearch {
-		for c, letter := range row {
-			if letter == 'X' {
-				wordCount += problemInput.CountAtLocation(r, c)
-			}
-		}
+	for _, p := range problemInput.WordSearch.FindAll('X') {
+		wordCount += problemInput.CountAtLocation(p.Row, p.Col)
 	}
 
-	return strconv.Itoa(wordCount)
+	return common.IntAnswer(wordCount)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 
 	xCount := 0
-	for r, row := range problemInput.WordSearch {
-		for c, letter := range row {
-			if letter == 'A' {
-				xCount += problemInput.CountX(r, c)
-			}
-		}
+	for _, p := range problemInput.WordSearch.FindAll('A') {
+		xCount += problemInput.CountX(p.Row, p.Col)
 	}
 
-	return strconv.Itoa(xCount)
+	return common.IntAnswer(xCount)
 }
 
 type ProblemInput struct {
-	WordSearch [][]rune
+	WordSearch *common.Grid[rune]
 }
 
 func (pi *ProblemInput) CountX(row, col int) int {
@@ -144,29 +129,14 @@ func (pi *ProblemInput) CheckHorizontalBackwards(row, col int) int {
 }
 
 func (pi *ProblemInput) E(row, col int, letter rune) bool {
-	// check inbounds
-	if row < 0 || row >= len(pi.WordSearch) {
-		return false
-	}
-	if col < 0 || col >= len(pi.WordSearch[row]) {
-		return false
-	}
-
-	return pi.WordSearch[row][col] == letter
+	// out of bounds is never a letter
+	return pi.WordSearch.At(common.Point{Row: row, Col: col}) == letter
 }
 
 func parseInput(input string) ProblemInput {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
-
-	result := make([][]rune, len(lines))
-	for r, row := range lines {
-		result[r] = make([]rune, len(row))
-		for c, letter := range row {
-			result[r][c] = letter
-		}
-	}
+	defer common.TimeParsing()()
 
 	return ProblemInput{
-		WordSearch: result,
+		WordSearch: common.MustParseGrid(input),
 	}
 }
@@ -1,4 +1,4 @@
-package main
+package day05
 
 import (
 	_ "embed"
@@ -12,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 5, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,24 +23,24 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	result := 0
 	for _, pageOrder := range problemInput.ValidPageOrders() {
 		middlePage := pageOrder.pages[len(pageOrder.pages)/2]
 		result += middlePage
 	}
-	return strconv.Itoa(result)
+	return common.IntAnswer(result)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	result := 0
 	for _, invalidPageOrder := range problemInput.InvalidPageOrders() {
 		correctedPageOrder := invalidPageOrder.Correct(problemInput.rules)
 		result += correctedPageOrder.pages[len(correctedPageOrder.pages)/2]
 	}
-	return strconv.Itoa(result)
+	return common.IntAnswer(result)
 }
 
 type PageOrder struct {
@@ -141,6 +135,8 @@ func (pi *ProblemInput) InvalidPageOrders() []PageOrder {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := strings.Split(strings.TrimSpace(input), "\n")
 
 	problemInput := ProblemInput{
@@ -1,8 +1,7 @@
-package main
+package day07
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 7, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,24 +22,24 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	equations := problemInput.EquationsWithSolutionsPart1()
 	sumOfValues := 0
 	for _, equation := range equations {
 		sumOfValues += equation.Value
 	}
-	return strconv.Itoa(sumOfValues)
+	return common.IntAnswer(sumOfValues)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	equations := problemInput.EquationsWithSolutionsPart2()
 	sumOfValues := 0
 	for _, equation := range equations {
 		sumOfValues += equation.Value
 	}
-	return strconv.Itoa(sumOfValues)
+	return common.IntAnswer(sumOfValues)
 }
 
 type Equation struct {
@@ -131,6 +124,8 @@ func (pi *ProblemInput) EquationsWithSolutionsPart2() []Equation {
 }
 
 func parseInput(input string) ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := strings.Split(strings.TrimSpace(input), "\n")
 	result := ProblemInput{
 		Equations: make([]Equation, 0, len(lines)),
@@ -1,8 +1,7 @@
-package main
+package day08
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 8, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,31 +22,23 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	antinodeCount := problemInput.AnnotateAntinodesPart1()
-	return strconv.Itoa(antinodeCount)
+	return common.IntAnswer(antinodeCount)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	antinodeCount := problemInput.AnnotateAntinodesPart2()
-	return strconv.Itoa(antinodeCount)
-}
-
-type Point struct {
-	Row, Col int
-}
-
-func (p *Point) Equal(other Point) bool {
-	return p.Row == other.Row && p.Col == other.Col
+	return common.IntAnswer(antinodeCount)
 }
 
 type ProblemInput struct {
-	Grid              [][]rune
-	AntinodeLocations [][]rune
+	Grid              *common.Grid[rune]
+	AntinodeLocations *common.Grid[rune]
 
-	AntennaLocations map[rune][]Point
+	AntennaLocations map[rune][]common.Point
 }
 
 func (pi *ProblemInput) AnnotateAntinodesPart1() int {
@@ -64,23 +49,20 @@ func (pi *ProblemInput) AnnotateAntinodesPart1() int {
 		for _, firstAntenna := range antennaLocations {
 			for _, secondAntenna := range antennaLocations {
 				// Skip if the same antenna
-				if firstAntenna.Equal(secondAntenna) {
+				if firstAntenna == secondAntenna {
 					continue
 				}
 
 				// Mark all antinodes created by this pair of antennas
-				deltaRow := secondAntenna.Row - firstAntenna.Row
-				deltaCol := secondAntenna.Col - firstAntenna.Col
+				delta := secondAntenna.Sub(firstAntenna)
 
-				possibleAntinode1 := Point{secondAntenna.Row + deltaRow, secondAntenna.Col + deltaCol}
-				possibleAntinode2 := Point{firstAntenna.Row - deltaRow, firstAntenna.Col - deltaCol}
+				possibleAntinode1 := secondAntenna.Add(delta)
+				possibleAntinode2 := firstAntenna.Sub(delta)
 
-				for _, possibleAntinode := range []Point{possibleAntinode1, possibleAntinode2} {
-					if pi.IsInGrid(possibleAntinode) {
-						if pi.AntinodeLocations[possibleAntinode.Row][possibleAntinode.Col] == '.' {
-							pi.AntinodeLocations[possibleAntinode.Row][possibleAntinode.Col] = '#'
-							count++
-						}
+				for _, possibleAntinode := range []common.Point{possibleAntinode1, possibleAntinode2} {
+					if pi.AntinodeLocations.At(possibleAntinode) == '.' {
+						pi.AntinodeLocations.Set(possibleAntinode, '#')
+						count++
 					}
 				}
 			}
@@ -98,36 +80,19 @@ func (pi *ProblemInput) AnnotateAntinodesPart2() int {
 		for _, firstAntenna := range antennaLocations {
 			for _, secondAntenna := range antennaLocations {
 				// Skip if the same antenna
-				if firstAntenna.Equal(secondAntenna) {
+				if firstAntenna == secondAntenna {
 					continue
 				}
 
-				// Mark all antinodes created by this pair of antennas
-				deltaRow := secondAntenna.Row - firstAntenna.Row
-				deltaCol := secondAntenna.Col - firstAntenna.Col
-
-				lastAntinode := Point{firstAntenna.Row, firstAntenna.Col}
-
-				// Mark the first antinode
-				if pi.AntinodeLocations[lastAntinode.Row][lastAntinode.Col] == '.' {
-					pi.AntinodeLocations[lastAntinode.Row][lastAntinode.Col] = '#'
-					count++
-				}
-
-				directions := []int{-1, 1}
-				for _, direction := range directions {
-					for {
-						nextAntinode := Point{lastAntinode.Row + direction*deltaRow, lastAntinode.Col + direction*deltaCol}
-						if !pi.IsInGrid(nextAntinode) {
-							break
-						}
-
-						if pi.AntinodeLocations[nextAntinode.Row][nextAntinode.Col] == '.' {
-							pi.AntinodeLocations[nextAntinode.Row][nextAntinode.Col] = '#'
+				// Mark all antinodes created by this pair of antennas, every
+				// point of the grid in line with both of them
+				step := firstAntenna.StepToward(secondAntenna)
+				for _, direction := range []common.Point{step, step.Neg()} {
+					for antinode := firstAntenna; pi.Grid.InBounds(antinode); antinode = antinode.Add(direction) {
+						if pi.AntinodeLocations.At(antinode) == '.' {
+							pi.AntinodeLocations.Set(antinode, '#')
 							count++
 						}
-
-						lastAntinode = nextAntinode
 					}
 				}
 			}
@@ -137,50 +102,30 @@ func (pi *ProblemInput) AnnotateAntinodesPart2() int {
 	return count
 }
 
-func (pi *ProblemInput) IsInGrid(p Point) bool {
-	return p.Row >= 0 && p.Row < len(pi.Grid) && p.Col >= 0 && p.Col < len(pi.Grid[p.Row])
-}
-
 func (pi *ProblemInput) String() string {
-	var sb strings.Builder
-	for _, row := range pi.Grid {
-		sb.WriteString(string(row))
-		sb.WriteString("\n")
-	}
-	return sb.String()
+	return pi.Grid.String()
 }
 
 func (pi *ProblemInput) StringAntinodeLocations() string {
-	var sb strings.Builder
-	for _, row := range pi.AntinodeLocations {
-		sb.WriteString(string(row))
-		sb.WriteString("\n")
-	}
-	return sb.String()
+	return pi.AntinodeLocations.String()
 }
 
 func parseInput(input string) ProblemInput {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
+	defer common.TimeParsing()()
 
+	grid := common.MustParseGrid(input)
 	result := ProblemInput{
-		Grid:              make([][]rune, len(lines)),
-		AntinodeLocations: make([][]rune, len(lines)),
-		AntennaLocations:  map[rune][]Point{},
+		Grid:              grid,
+		AntinodeLocations: common.NewGrid[rune](grid.Rows, grid.Cols),
+		AntennaLocations:  map[rune][]common.Point{},
 	}
-	for i, row := range lines {
-		result.Grid[i] = make([]rune, len(row))
-		result.AntinodeLocations[i] = make([]rune, len(row))
-		for j, element := range row {
-			result.Grid[i][j] = element
-			result.AntinodeLocations[i][j] = '.'
-
-			// if element is lowercase letter, uppercase letter, or digit
-			if (element >= 'a' && element <= 'z') || (element >= 'A' && element <= 'Z') || (element >= '0' && element <= '9') {
-				if _, ok := result.AntennaLocations[element]; !ok {
-					result.AntennaLocations[element] = []Point{}
-				}
-				result.AntennaLocations[element] = append(result.AntennaLocations[element], Point{i, j})
-			}
+	for _, p := range grid.Points() {
+		element := grid.At(p)
+		result.AntinodeLocations.Set(p, '.')
+
+		// if element is lowercase letter, uppercase letter, or digit
+		if (element >= 'a' && element <= 'z') || (element >= 'A' && element <= 'Z') || (element >= '0' && element <= '9') {
+			result.AntennaLocations[element] = append(result.AntennaLocations[element], p)
 		}
 	}
 
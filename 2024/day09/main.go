@@ -1,8 +1,7 @@
-package main
+package day09
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 9, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,20 +22,20 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	problemInput.ExpandDiskMap()
 	problemInput.CompactPart1()
 	checksum := problemInput.ComputeChecksum()
-	return strconv.Itoa(checksum)
+	return common.IntAnswer(checksum)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	problemInput.ExpandDiskMap()
 	problemInput.CompactPart2()
 	checksum := problemInput.ComputeChecksum()
-	return strconv.Itoa(checksum)
+	return common.IntAnswer(checksum)
 }
 
 type ProblemInput struct {
@@ -183,6 +176,8 @@ func (pi *ProblemInput) ExpandDiskMap() {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	problemInput := &ProblemInput{
 		DiskMap:                []int{},
 		ExpandedDiskMap:        []int{},
@@ -1,8 +1,7 @@
-package main
+package day10
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 10, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,24 +22,20 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
-	return strconv.Itoa(problemInput.ScoreTopology(true))
+	return common.IntAnswer(problemInput.ScoreTopology(true))
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
-	return strconv.Itoa(problemInput.ScoreTopology(false))
-}
-
-type Point struct {
-	Row, Col int
+	return common.IntAnswer(problemInput.ScoreTopology(false))
 }
 
 type ProblemInput struct {
-	Topology [][]int
+	Topology *common.Grid[int]
 
-	Trailheads []Point
+	Trailheads []common.Point
 }
 
 func (pi *ProblemInput) ScoreTopology(part1 bool) int {
@@ -64,10 +53,10 @@ func (pi *ProblemInput) ScoreTopology(part1 bool) int {
 }
 
 // ScoreTrailhead returns the number of 9-height positions reachable from the trailhead, gradually increasing by exactly 1 height at each step.
-func (pi *ProblemInput) ScoreTrailheadPart2(th Point) int {
+func (pi *ProblemInput) ScoreTrailheadPart2(th common.Point) int {
 	score := 0
 
-	height := pi.Topology[th.Row][th.Col]
+	height := pi.Topology.At(th)
 
 	// base case
 	if height == 9 {
@@ -83,10 +72,10 @@ func (pi *ProblemInput) ScoreTrailheadPart2(th Point) int {
 }
 
 // ScoreTrailhead returns the number of 9-height positions reachable from the trailhead, gradually increasing by exactly 1 height at each step.
-func (pi *ProblemInput) ScoreTrailheadPart1(th Point) int {
+func (pi *ProblemInput) ScoreTrailheadPart1(th common.Point) int {
 	score := 0
 	peaks := pi.GetReachablePeaks(th)
-	seen := map[Point]bool{}
+	seen := map[common.Point]bool{}
 	for _, peak := range peaks {
 		if ok := seen[peak]; !ok {
 			seen[peak] = true
@@ -97,14 +86,14 @@ func (pi *ProblemInput) ScoreTrailheadPart1(th Point) int {
 	return score
 }
 
-func (pi *ProblemInput) GetReachablePeaks(p Point) []Point {
-	peaks := []Point{}
+func (pi *ProblemInput) GetReachablePeaks(p common.Point) []common.Point {
+	peaks := []common.Point{}
 
-	height := pi.Topology[p.Row][p.Col]
+	height := pi.Topology.At(p)
 
 	// base case
 	if height == 9 {
-		return []Point{p}
+		return []common.Point{p}
 	}
 
 	// recursive case
@@ -115,18 +104,12 @@ func (pi *ProblemInput) GetReachablePeaks(p Point) []Point {
 	return peaks
 }
 
-func (pi *ProblemInput) GetPossibleMoves(th Point, h int) []Point {
-	possibleMoves := []Point{}
-	height := pi.Topology[th.Row][th.Col]
-	// attempt to move up, down, left, right
-	for _, move := range []Point{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
-		possibleNextPoint := Point{Row: th.Row + move.Row, Col: th.Col + move.Col}
-		if !pi.IsInBounds(possibleNextPoint) {
-			continue
-		}
-
-		nextHeight := pi.Topology[possibleNextPoint.Row][possibleNextPoint.Col]
-		if nextHeight == height+1 {
+func (pi *ProblemInput) GetPossibleMoves(th common.Point, h int) []common.Point {
+	possibleMoves := []common.Point{}
+	height := pi.Topology.At(th)
+	// attempt to move up, right, down, left
+	for _, possibleNextPoint := range pi.Topology.Neighbors4(th) {
+		if pi.Topology.At(possibleNextPoint) == height+1 {
 			// we can move here
 			possibleMoves = append(possibleMoves, possibleNextPoint)
 		}
@@ -135,26 +118,18 @@ func (pi *ProblemInput) GetPossibleMoves(th Point, h int) []Point {
 	return possibleMoves
 }
 
-func (pi *ProblemInput) IsInBounds(p Point) bool {
-	return p.Row >= 0 && p.Row < len(pi.Topology) && p.Col >= 0 && p.Col < len(pi.Topology[0])
-}
-
 func parseInput(input string) ProblemInput {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
-
-	pi := ProblemInput{
-		Topology: make([][]int, len(lines)),
+	defer common.TimeParsing()()
+
+	// Anything but a digit, like the . of some examples, is never one
+	// higher than its neighbors.
+	topology, err := common.ParseGridFunc(input, func(r rune) (int, error) {
+		return int(r - '0'), nil
+	})
+	common.CheckErr(err, "Failed to parse topology")
+
+	return ProblemInput{
+		Topology:   topology,
+		Trailheads: topology.FindAll(0),
 	}
-
-	for i, row := range lines {
-		pi.Topology[i] = make([]int, len(row))
-		for j, element := range row {
-			pi.Topology[i][j] = int(element - '0')
-			if element == '0' {
-				pi.Trailheads = append(pi.Trailheads, Point{Row: i, Col: j})
-			}
-		}
-	}
-
-	return pi
 }
@@ -1,4 +1,4 @@
-package main
+package day11
 
 import (
 	_ "embed"
@@ -12,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 11, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,18 +23,18 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	problemInput.Blink(25, false)
 	numberOfStones := problemInput.CountStones(false)
-	return strconv.Itoa(numberOfStones)
+	return common.IntAnswer(numberOfStones)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	problemInput.Blink(75, false)
 	numberOfStones := problemInput.CountStones(false)
-	return strconv.Itoa(numberOfStones)
+	return common.IntAnswer(numberOfStones)
 }
 
 type ProblemInput struct {
@@ -142,6 +136,8 @@ func (pi *ProblemInput) SingleBlinkPart1() {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	cleanInput := strings.TrimSpace(input)
 	strStones := strings.Fields(cleanInput)
 
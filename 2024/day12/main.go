@@ -1,8 +1,7 @@
-package main
+package day12
 
 import (
 	_ "embed"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 12, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,33 +22,29 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	cost := problemInput.ComputeFenceCost(false)
-	return strconv.Itoa(cost)
+	return common.IntAnswer(cost)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	cost := problemInput.ComputeFenceCost(true)
-	return strconv.Itoa(cost)
-}
-
-type Point struct {
-	Row, Col int
+	return common.IntAnswer(cost)
 }
 
 type ProblemInput struct {
-	Garden             [][]rune
-	Visited            map[Point]bool
+	Garden             *common.Grid[rune]
+	Visited            map[common.Point]bool
 	PlantProcessedMap  map[rune]bool
-	PlantToPositionMap map[rune][]Point
+	PlantToPositionMap map[rune][]common.Point
 }
 
 // Regions are defined as a contiguous group of plants of the same type
 type Region struct {
 	PlantType rune
-	Points    []Point
+	Points    []common.Point
 }
 
 func (pi *ProblemInput) ComputeFenceCost(bulkDiscount bool) int {
@@ -76,7 +65,7 @@ func (pi *ProblemInput) ComputeFenceCost(bulkDiscount bool) int {
 }
 
 type Side struct {
-	Start, End Point
+	Start, End common.Point
 	Label      string
 }
 
@@ -93,21 +82,21 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 		// a point can contribute up to 4 sides
 
 		// 1. try top horizontal side
-		topPoint := Point{Row: point.Row - 1, Col: point.Col}
-		if !pi.IsInBounds(topPoint) || pi.Garden[topPoint.Row][topPoint.Col] != region.PlantType {
+		topPoint := common.Point{Row: point.Row - 1, Col: point.Col}
+		if !pi.Garden.InBounds(topPoint) || pi.Garden.At(topPoint) != region.PlantType {
 			// this is part of a side
 
 			// find the start of the side
 			start := point
 			end := point
 			for {
-				newStart := Point{Row: start.Row, Col: start.Col - 1}
+				newStart := common.Point{Row: start.Row, Col: start.Col - 1}
 
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newStart) && pi.Garden[newStart.Row][newStart.Col] == region.PlantType {
-					newStartTop := Point{Row: newStart.Row - 1, Col: newStart.Col}
-					if !pi.IsInBounds(newStartTop) || pi.Garden[newStartTop.Row][newStartTop.Col] != region.PlantType {
+				if pi.Garden.InBounds(newStart) && pi.Garden.At(newStart) == region.PlantType {
+					newStartTop := common.Point{Row: newStart.Row - 1, Col: newStart.Col}
+					if !pi.Garden.InBounds(newStartTop) || pi.Garden.At(newStartTop) != region.PlantType {
 						start = newStart
 					} else {
 						break
@@ -118,12 +107,12 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 			}
 
 			for {
-				newEnd := Point{Row: end.Row, Col: end.Col + 1}
+				newEnd := common.Point{Row: end.Row, Col: end.Col + 1}
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newEnd) && pi.Garden[newEnd.Row][newEnd.Col] == region.PlantType {
-					newStartTop := Point{Row: newEnd.Row - 1, Col: newEnd.Col}
-					if !pi.IsInBounds(newStartTop) || pi.Garden[newStartTop.Row][newStartTop.Col] != region.PlantType {
+				if pi.Garden.InBounds(newEnd) && pi.Garden.At(newEnd) == region.PlantType {
+					newStartTop := common.Point{Row: newEnd.Row - 1, Col: newEnd.Col}
+					if !pi.Garden.InBounds(newStartTop) || pi.Garden.At(newStartTop) != region.PlantType {
 						end = newEnd
 					} else {
 						break
@@ -141,21 +130,21 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 		}
 
 		// 2. try bottom horizontal side
-		bottomPoint := Point{Row: point.Row + 1, Col: point.Col}
-		if !pi.IsInBounds(bottomPoint) || pi.Garden[bottomPoint.Row][bottomPoint.Col] != region.PlantType {
+		bottomPoint := common.Point{Row: point.Row + 1, Col: point.Col}
+		if !pi.Garden.InBounds(bottomPoint) || pi.Garden.At(bottomPoint) != region.PlantType {
 			// this is part of a side
 
 			// find the start of the side
 			start := point
 			end := point
 			for {
-				newStart := Point{Row: start.Row, Col: start.Col - 1}
+				newStart := common.Point{Row: start.Row, Col: start.Col - 1}
 
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newStart) && pi.Garden[newStart.Row][newStart.Col] == region.PlantType {
-					newStartBottom := Point{Row: newStart.Row + 1, Col: newStart.Col}
-					if !pi.IsInBounds(newStartBottom) || pi.Garden[newStartBottom.Row][newStartBottom.Col] != region.PlantType {
+				if pi.Garden.InBounds(newStart) && pi.Garden.At(newStart) == region.PlantType {
+					newStartBottom := common.Point{Row: newStart.Row + 1, Col: newStart.Col}
+					if !pi.Garden.InBounds(newStartBottom) || pi.Garden.At(newStartBottom) != region.PlantType {
 						start = newStart
 					} else {
 						break
@@ -166,12 +155,12 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 			}
 
 			for {
-				newEnd := Point{Row: end.Row, Col: end.Col + 1}
+				newEnd := common.Point{Row: end.Row, Col: end.Col + 1}
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newEnd) && pi.Garden[newEnd.Row][newEnd.Col] == region.PlantType {
-					newEndBottom := Point{Row: newEnd.Row + 1, Col: newEnd.Col}
-					if !pi.IsInBounds(newEndBottom) || pi.Garden[newEndBottom.Row][newEndBottom.Col] != region.PlantType {
+				if pi.Garden.InBounds(newEnd) && pi.Garden.At(newEnd) == region.PlantType {
+					newEndBottom := common.Point{Row: newEnd.Row + 1, Col: newEnd.Col}
+					if !pi.Garden.InBounds(newEndBottom) || pi.Garden.At(newEndBottom) != region.PlantType {
 						end = newEnd
 					} else {
 						break
@@ -189,21 +178,21 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 		}
 
 		// 3. try left vertical side
-		leftPoint := Point{Row: point.Row, Col: point.Col - 1}
-		if !pi.IsInBounds(leftPoint) || pi.Garden[leftPoint.Row][leftPoint.Col] != region.PlantType {
+		leftPoint := common.Point{Row: point.Row, Col: point.Col - 1}
+		if !pi.Garden.InBounds(leftPoint) || pi.Garden.At(leftPoint) != region.PlantType {
 			// this is part of a side
 
 			// find the start of the side
 			start := point
 			end := point
 			for {
-				newStart := Point{Row: start.Row - 1, Col: start.Col}
+				newStart := common.Point{Row: start.Row - 1, Col: start.Col}
 
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newStart) && pi.Garden[newStart.Row][newStart.Col] == region.PlantType {
-					newStartLeft := Point{Row: newStart.Row, Col: newStart.Col - 1}
-					if !pi.IsInBounds(newStartLeft) || pi.Garden[newStartLeft.Row][newStartLeft.Col] != region.PlantType {
+				if pi.Garden.InBounds(newStart) && pi.Garden.At(newStart) == region.PlantType {
+					newStartLeft := common.Point{Row: newStart.Row, Col: newStart.Col - 1}
+					if !pi.Garden.InBounds(newStartLeft) || pi.Garden.At(newStartLeft) != region.PlantType {
 						start = newStart
 					} else {
 						break
@@ -214,12 +203,12 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 			}
 
 			for {
-				newEnd := Point{Row: end.Row + 1, Col: end.Col}
+				newEnd := common.Point{Row: end.Row + 1, Col: end.Col}
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newEnd) && pi.Garden[newEnd.Row][newEnd.Col] == region.PlantType {
-					newEndLeft := Point{Row: newEnd.Row, Col: newEnd.Col - 1}
-					if !pi.IsInBounds(newEndLeft) || pi.Garden[newEndLeft.Row][newEndLeft.Col] != region.PlantType {
+				if pi.Garden.InBounds(newEnd) && pi.Garden.At(newEnd) == region.PlantType {
+					newEndLeft := common.Point{Row: newEnd.Row, Col: newEnd.Col - 1}
+					if !pi.Garden.InBounds(newEndLeft) || pi.Garden.At(newEndLeft) != region.PlantType {
 						end = newEnd
 					} else {
 						break
@@ -237,21 +226,21 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 		}
 
 		// 4. try right vertical side
-		rightPoint := Point{Row: point.Row, Col: point.Col + 1}
-		if !pi.IsInBounds(rightPoint) || pi.Garden[rightPoint.Row][rightPoint.Col] != region.PlantType {
+		rightPoint := common.Point{Row: point.Row, Col: point.Col + 1}
+		if !pi.Garden.InBounds(rightPoint) || pi.Garden.At(rightPoint) != region.PlantType {
 			// this is part of a side
 
 			// find the start of the side
 			start := point
 			end := point
 			for {
-				newStart := Point{Row: start.Row - 1, Col: start.Col}
+				newStart := common.Point{Row: start.Row - 1, Col: start.Col}
 
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newStart) && pi.Garden[newStart.Row][newStart.Col] == region.PlantType {
-					newStartRight := Point{Row: newStart.Row, Col: newStart.Col + 1}
-					if !pi.IsInBounds(newStartRight) || pi.Garden[newStartRight.Row][newStartRight.Col] != region.PlantType {
+				if pi.Garden.InBounds(newStart) && pi.Garden.At(newStart) == region.PlantType {
+					newStartRight := common.Point{Row: newStart.Row, Col: newStart.Col + 1}
+					if !pi.Garden.InBounds(newStartRight) || pi.Garden.At(newStartRight) != region.PlantType {
 						start = newStart
 					} else {
 						break
@@ -262,12 +251,12 @@ func (pi *ProblemInput) ComputeCostForRegionBulkDiscount(region Region) int {
 			}
 
 			for {
-				newEnd := Point{Row: end.Row + 1, Col: end.Col}
+				newEnd := common.Point{Row: end.Row + 1, Col: end.Col}
 				// must be the same plant type and inbounds
 				// add the top of it is out of bounds or not the same plant type
-				if pi.IsInBounds(newEnd) && pi.Garden[newEnd.Row][newEnd.Col] == region.PlantType {
-					newEndRight := Point{Row: newEnd.Row, Col: newEnd.Col + 1}
-					if !pi.IsInBounds(newEndRight) || pi.Garden[newEndRight.Row][newEndRight.Col] != region.PlantType {
+				if pi.Garden.InBounds(newEnd) && pi.Garden.At(newEnd) == region.PlantType {
+					newEndRight := common.Point{Row: newEnd.Row, Col: newEnd.Col + 1}
+					if !pi.Garden.InBounds(newEndRight) || pi.Garden.At(newEndRight) != region.PlantType {
 						end = newEnd
 					} else {
 						break
@@ -295,13 +284,12 @@ func (pi *ProblemInput) ComputeCostForRegion(region Region) int {
 	perimeter := 0
 
 	for _, point := range region.Points {
-		// each point can contribute up to 4 to the perimeter
-		contribution := 0
-		for _, neighbor := range []Point{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
-			nextPoint := Point{Row: point.Row + neighbor.Row, Col: point.Col + neighbor.Col}
-			// if the neighbor is out of bounds or not the same plant type, it contributes to the perimeter
-			if !pi.IsInBounds(nextPoint) || pi.Garden[nextPoint.Row][nextPoint.Col] != region.PlantType {
-				contribution++
+		// each point can contribute up to 4 to the perimeter, every
+		// neighbor of the same plant type takes one side away
+		contribution := 4
+		for _, nextPoint := range pi.Garden.Neighbors4(point) {
+			if pi.Garden.At(nextPoint) == region.PlantType {
+				contribution--
 			}
 		}
 		perimeter += contribution
@@ -314,7 +302,7 @@ func (pi *ProblemInput) Regions(plantType rune) []Region {
 	regions := make([]Region, 0)
 
 	points := pi.PlantToPositionMap[plantType]
-	seen := make(map[Point]bool)
+	seen := make(map[common.Point]bool)
 	for _, point := range points {
 		// Have we seen this point before?
 		if _, ok := seen[point]; ok {
@@ -322,9 +310,9 @@ func (pi *ProblemInput) Regions(plantType rune) []Region {
 		}
 
 		// Start a new region
-		region := Region{PlantType: plantType, Points: make([]Point, 0)}
-		pointsToProcess := []Point{point}
-		pointsProcessed := make(map[Point]bool)
+		region := Region{PlantType: plantType, Points: make([]common.Point, 0)}
+		pointsToProcess := []common.Point{point}
+		pointsProcessed := make(map[common.Point]bool)
 		for len(pointsToProcess) > 0 {
 			// pop the first point
 			point := pointsToProcess[0]
@@ -340,9 +328,8 @@ func (pi *ProblemInput) Regions(plantType rune) []Region {
 			pointsProcessed[point] = true
 
 			// Add the neighbors to the points to process
-			for _, neighbor := range []Point{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
-				nextPoint := Point{Row: point.Row + neighbor.Row, Col: point.Col + neighbor.Col}
-				if pi.IsInBounds(nextPoint) && pi.Garden[nextPoint.Row][nextPoint.Col] == plantType {
+			for _, nextPoint := range pi.Garden.Neighbors4(point) {
+				if pi.Garden.At(nextPoint) == plantType {
 					if _, ok := pointsProcessed[nextPoint]; !ok {
 						pointsToProcess = append(pointsToProcess, nextPoint)
 					}
@@ -362,30 +349,20 @@ func (pi *ProblemInput) Regions(plantType rune) []Region {
 	return regions
 }
 
-func (pi *ProblemInput) IsInBounds(point Point) bool {
-	return point.Row >= 0 && point.Row < len(pi.Garden) && point.Col >= 0 && point.Col < len(pi.Garden[0])
-}
-
 func parseInput(input string) *ProblemInput {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
+	defer common.TimeParsing()()
 
 	pi := &ProblemInput{
-		Garden:             make([][]rune, len(lines)),
-		Visited:            make(map[Point]bool),
+		Garden:             common.MustParseGrid(input),
+		Visited:            make(map[common.Point]bool),
 		PlantProcessedMap:  make(map[rune]bool),
-		PlantToPositionMap: make(map[rune][]Point),
+		PlantToPositionMap: make(map[rune][]common.Point),
 	}
 
-	for i, row := range lines {
-		pi.Garden[i] = []rune(row)
-		for j, element := range row {
-			pi.PlantProcessedMap[element] = false
-			point := Point{Row: i, Col: j}
-			if _, ok := pi.PlantToPositionMap[element]; !ok {
-				pi.PlantToPositionMap[element] = make([]Point, 0)
-			}
-			pi.PlantToPositionMap[element] = append(pi.PlantToPositionMap[element], point)
-		}
+	for _, point := range pi.Garden.Points() {
+		element := pi.Garden.At(point)
+		pi.PlantProcessedMap[element] = false
+		pi.PlantToPositionMap[element] = append(pi.PlantToPositionMap[element], point)
 	}
 
 	return pi
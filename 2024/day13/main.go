@@ -1,8 +1,7 @@
-package main
+package day13
 
 import (
 	_ "embed"
-	"fmt"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 13, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -29,24 +22,16 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	cost := problemInput.TotalCostToPrizes(false)
-	return fmt.Sprintf("%v", cost)
+	return common.Int64Answer(cost)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	cost := problemInput.TotalCostToPrizes(true)
-	return fmt.Sprintf("%v", cost)
-}
-
-type Point struct {
-	X, Y int64
-}
-
-type Vector struct {
-	X, Y int64
+	return common.Int64Answer(cost)
 }
 
 const (
@@ -54,33 +39,34 @@ const (
 	COST_OF_B_BUTTON = 1
 )
 
+// ClawGame keeps X in the columns and Y in the rows of its points.
 type ClawGame struct {
-	ButtonA Vector
-	ButtonB Vector
+	ButtonA common.Point
+	ButtonB common.Point
 
-	Prize Point
+	Prize common.Point
 }
 
 // CostToPrize returns the lowest cost to get the prize
 // returns -1 if the prize is unreachable
-func (cg *ClawGame) CostToPrize(startingPoint Point, seen map[Point]int) int {
+func (cg *ClawGame) CostToPrize(startingPoint common.Point, seen map[common.Point]int) int {
 	// are we past the prize?
-	if startingPoint.X > cg.Prize.X || startingPoint.Y > cg.Prize.Y {
+	if startingPoint.Col > cg.Prize.Col || startingPoint.Row > cg.Prize.Row {
 		return -1
 	}
 
 	// are we at the prize?
-	if startingPoint.X == cg.Prize.X && startingPoint.Y == cg.Prize.Y {
+	if startingPoint == cg.Prize {
 		return 0
 	}
 
-	nextAfterA := Point{startingPoint.X + cg.ButtonA.X, startingPoint.Y + cg.ButtonA.Y}
+	nextAfterA := startingPoint.Add(cg.ButtonA)
 	if _, ok := seen[nextAfterA]; !ok {
 		seen[nextAfterA] = cg.CostToPrize(nextAfterA, seen)
 	}
 	costOfARoute := seen[nextAfterA]
 
-	nextAfterB := Point{startingPoint.X + cg.ButtonB.X, startingPoint.Y + cg.ButtonB.Y}
+	nextAfterB := startingPoint.Add(cg.ButtonB)
 	if _, ok := seen[nextAfterB]; !ok {
 		seen[nextAfterB] = cg.CostToPrize(nextAfterB, seen)
 	}
@@ -113,22 +99,24 @@ func (pi *ProblemInput) TotalCostToPrizes(withConversionError bool) int64 {
 		// use system of equations to solve for aPresses and bPresses
 		// should have started here .... lol
 		if withConversionError {
+			// int64 and not common.Point, the prizes are 1e13 away and
+			// the products below need 64 bits wherever int does not have them
 			err := int64(10000000000000)
-			cg.Prize.X += err
-			cg.Prize.Y += err
+			ax, ay := int64(cg.ButtonA.Col), int64(cg.ButtonA.Row)
+			bx, by := int64(cg.ButtonB.Col), int64(cg.ButtonB.Row)
+			px, py := int64(cg.Prize.Col)+err, int64(cg.Prize.Row)+err
 
-			aPresses := (cg.Prize.Y*cg.ButtonB.X - cg.Prize.X*cg.ButtonB.Y) / (cg.ButtonA.Y*cg.ButtonB.X - cg.ButtonA.X*cg.ButtonB.Y)
-			bPresses := (cg.Prize.X - aPresses*cg.ButtonA.X) / cg.ButtonB.X
+			// Cramer's rule, buttons are never parallel in the input
+			det := ax*by - ay*bx
+			aPresses := (px*by - py*bx) / det
+			bPresses := (ax*py - ay*px) / det
 
-			tx := aPresses*cg.ButtonA.X + bPresses*cg.ButtonB.X
-			ty := aPresses*cg.ButtonA.Y + bPresses*cg.ButtonB.Y
-
-			if tx == cg.Prize.X && ty == cg.Prize.Y {
-				totalCost += aPresses*int64(COST_OF_A_BUTTON) + bPresses*int64(COST_OF_B_BUTTON)
+			if aPresses*ax+bPresses*bx == px && aPresses*ay+bPresses*by == py {
+				totalCost += aPresses*COST_OF_A_BUTTON + bPresses*COST_OF_B_BUTTON
 			}
 
 		} else {
-			cost := cg.CostToPrize(Point{0, 0}, map[Point]int{})
+			cost := cg.CostToPrize(common.Point{}, map[common.Point]int{})
 			if cost == -1 { // prize is unreachable
 				continue
 			}
@@ -139,6 +127,8 @@ func (pi *ProblemInput) TotalCostToPrizes(withConversionError bool) int64 {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	pi := &ProblemInput{
 		ClawGames: make([]ClawGame, 0),
 	}
@@ -159,7 +149,7 @@ func parseInput(input string) *ProblemInput {
 			}
 			x := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[0]), "X+"))
 			y := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[1]), "Y+"))
-			currentClawGame.ButtonA = Vector{int64(x), int64(y)}
+			currentClawGame.ButtonA = common.Point{Row: y, Col: x}
 		}
 
 		if strings.HasPrefix(line, "Button B: ") {
@@ -171,7 +161,7 @@ func parseInput(input string) *ProblemInput {
 			}
 			x := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[0]), "X+"))
 			y := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[1]), "Y+"))
-			currentClawGame.ButtonB = Vector{int64(x), int64(y)}
+			currentClawGame.ButtonB = common.Point{Row: y, Col: x}
 		}
 
 		if strings.HasPrefix(line, "Prize: ") {
@@ -183,7 +173,7 @@ func parseInput(input string) *ProblemInput {
 			}
 			x := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[0]), "X="))
 			y := common.MustAtoi(strings.TrimPrefix(strings.TrimSpace(parts[1]), "Y="))
-			currentClawGame.Prize = Point{int64(x), int64(y)}
+			currentClawGame.Prize = common.Point{Row: y, Col: x}
 
 			// copy the current claw game to the list and reset the current claw game
 			pi.ClawGames = append(pi.ClawGames, currentClawGame)
@@ -1,9 +1,9 @@
-package main
+package day14
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,189 +13,175 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 14, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
 // Solution
 //--------------------------------------------------------------------
 
-type solver struct{}
+type solver struct {
+	params common.Params
+}
+
+func (s *solver) Params() []common.Param {
+	return []common.Param{
+		{Name: "width", Default: "101", Usage: "width of the room, 11 in the example"},
+		{Name: "height", Default: "103", Usage: "height of the room, 7 in the example"},
+	}
+}
+
+func (s *solver) WithParams(params common.Params) common.ProblemSolver {
+	return &solver{params: params}
+}
+
+func (s *solver) room() common.Torus {
+	params := common.ParamsOrDefault(s, s.params)
+	return common.Torus{Rows: params.Int("height"), Cols: params.Int("width")}
+}
+
+func (s *solver) SolvePart1(input string) common.Answer {
+	answer, err := s.TrySolvePart1(context.Background(), input)
+	common.CheckErr(err, "Failed to solve part 1")
+	return answer
+}
 
-func (s *solver) SolvePart1(input string) string {
-	problemInput := parseInput(input)
+func (s *solver) SolvePart2(input string) common.Answer {
+	answer, err := s.TrySolvePart2(context.Background(), input)
+	common.CheckErr(err, "Failed to solve part 2")
+	return answer
+}
+
+func (s *solver) TrySolvePart1(ctx context.Context, input string) (common.Answer, error) {
+	problemInput, err := parseInput(input, s.room())
+	if err != nil {
+		return common.Answer{}, err
+	}
 	problemInput.Step(100)
 	safetyFactor := problemInput.ComputeSafetyfactor()
-	return strconv.Itoa(safetyFactor)
+	return common.IntAnswer(safetyFactor), nil
 }
 
-func (s *solver) SolvePart2(input string) string {
-	problemInput := parseInput(input)
+func (s *solver) TrySolvePart2(ctx context.Context, input string) (common.Answer, error) {
+	problemInput, err := parseInput(input, s.room())
+	if err != nil {
+		return common.Answer{}, err
+	}
 	lowestSafetyFactor := 1000000000000000
 	bestStep := 0
 	for i := 0; i < 10000; i++ {
+		if err := ctx.Err(); err != nil {
+			return common.Answer{}, err
+		}
 		problemInput.Step(1)
 		safetyFactor := problemInput.ComputeSafetyfactor()
 		if safetyFactor < lowestSafetyFactor {
 			lowestSafetyFactor = safetyFactor
 			bestStep = i
-			fmt.Printf("Step: %v, Safety Factor: %v\n", bestStep, safetyFactor)
-			problemInput.PrettyPrint()
+			common.Debugf("Step: %v, Safety Factor: %v\n%s", bestStep, safetyFactor, &problemInput)
 		}
 	}
-	return ""
+	return common.IntAnswer(bestStep+1).WithDiagnostic("lowest safety factor %d", lowestSafetyFactor), nil
 }
 
 //--------------------------------------------------------------------
 
-type RoomDimensions struct {
-	Width, Height int
-}
-
-var (
-	ExampleDimensions = RoomDimensions{11, 7}
-	RealDimensions    = RoomDimensions{101, 103}
-)
-
-type Point struct {
-	X, Y int
-}
-
-type Velocity struct {
-	Dx, Dy int
-}
-
+// Robot positions and velocities are points with X in Col and Y in Row.
 type Robot struct {
-	Position Point
-	Velocity Velocity
+	Position common.Point
+	Velocity common.Point
 }
 
 type ProblemInput struct {
-	Dimensions RoomDimensions
-	Robots     []Robot
+	Room   common.Torus
+	Robots []Robot
 }
 
 func (pi *ProblemInput) Step(numSteps int) {
-	for i := range pi.Robots {
-		// reference:
-		// r.x += n * r.vx
-		// r.x %= width
-		// r.y += n * r.vy
-		// r.y %= height
-		// if r.x < 0 {
-		// 	r.x += width
-		// }
-		// if r.y < 0 {
-		// 	r.y += height
-		// }
-
-		// Update X coordinate
-		pi.Robots[i].Position.X += numSteps * pi.Robots[i].Velocity.Dx
-		pi.Robots[i].Position.X %= pi.Dimensions.Width
-		if pi.Robots[i].Position.X < 0 {
-			pi.Robots[i].Position.X += pi.Dimensions.Width
-		}
-
-		// Update Y coordinate
-		pi.Robots[i].Position.Y += numSteps * pi.Robots[i].Velocity.Dy
-		pi.Robots[i].Position.Y %= pi.Dimensions.Height
-		if pi.Robots[i].Position.Y < 0 {
-			pi.Robots[i].Position.Y += pi.Dimensions.Height
-		}
-
+	for i, robot := range pi.Robots {
+		pi.Robots[i].Position = pi.Room.Step(robot.Position, robot.Velocity, numSteps)
 	}
 }
 
 func (pi *ProblemInput) ComputeSafetyfactor() int {
-	var counts [5]int
-
-	for _, robot := range pi.Robots {
-		quadrant := pi.WhichQuadrant(robot.Position)
-		counts[quadrant]++
+	positions := make([]common.Point, len(pi.Robots))
+	for i, robot := range pi.Robots {
+		positions[i] = robot.Position
 	}
+	counts := pi.Room.CountQuadrants(positions)
 
 	safetyFactor := counts[0] * counts[1] * counts[2] * counts[3]
 
 	return safetyFactor
 }
 
-func (pi *ProblemInput) WhichQuadrant(p Point) int {
-	// 0 = top left, 1 = top right, 2 = bottom left, 3 = bottom right 4 = on middle
-	if p.X < pi.Dimensions.Width/2 && p.Y < pi.Dimensions.Height/2 {
-		return 0
-	}
-	if p.X > pi.Dimensions.Width/2 && p.Y < pi.Dimensions.Height/2 {
-		return 1
-	}
-
-	if p.X < pi.Dimensions.Width/2 && p.Y > pi.Dimensions.Height/2 {
-		return 2
-	}
-
-	if p.X > pi.Dimensions.Width/2 && p.Y > pi.Dimensions.Height/2 {
-		return 3
+func (pi *ProblemInput) String() string {
+	var sb strings.Builder
+	counts := common.NewGrid[int](pi.Room.Rows, pi.Room.Cols)
+	for _, robot := range pi.Robots {
+		counts.Set(robot.Position, counts.At(robot.Position)+1)
 	}
-
-	return 4
-}
-
-func (pi *ProblemInput) PrettyPrint() {
-	for y := 0; y < pi.Dimensions.Height; y++ {
-		for x := 0; x < pi.Dimensions.Width; x++ {
-			count := 0
-			for _, robot := range pi.Robots {
-				if robot.Position.X == x && robot.Position.Y == y {
-					count++
-				}
-			}
+	for r := 0; r < counts.Rows; r++ {
+		for _, count := range counts.Row(r) {
 			if count > 0 {
-				fmt.Printf("%d", count)
+				fmt.Fprintf(&sb, "%d", count)
 			} else {
-				print(".")
+				sb.WriteString(".")
 			}
 		}
-		println()
+		sb.WriteString("\n")
 	}
+	return sb.String()
 }
 
-func parseInput(input string) ProblemInput {
-	lines := common.ReadAsLines(input)
+func parseInput(input string, room common.Torus) (ProblemInput, error) {
+	defer common.TimeParsing()()
 
-	pi := ProblemInput{}
-	if len(lines) > 20 {
-		pi.Dimensions = RealDimensions
-	} else {
-		pi.Dimensions = ExampleDimensions
+	pi := ProblemInput{Room: room}
+	if room.Rows < 1 || room.Cols < 1 {
+		return pi, fmt.Errorf("invalid room of %dx%d, want at least 1x1", room.Cols, room.Rows)
 	}
 
-	for _, line := range lines {
-		parts := strings.Fields(line)
-		if len(parts) != 2 {
-			panic("invalid line")
+	for _, line := range common.InputLines(input) {
+		fields := line.Fields()
+		if len(fields) != 2 {
+			return pi, line.Errorf("want p=x,y v=dx,dy, got %q", line.Text)
+		}
+		pos, err := parsePair(fields[0], "p=")
+		if err != nil {
+			return pi, err
 		}
-		posStr := strings.TrimPrefix(strings.TrimSpace(parts[0]), "p=")
-		posParts := strings.Split(posStr, ",")
-		pos := Point{common.MustAtoi(posParts[0]), common.MustAtoi(posParts[1])}
-		velStr := strings.TrimPrefix(strings.TrimSpace(parts[1]), "v=")
-		velParts := strings.Split(velStr, ",")
-		vel := Velocity{common.MustAtoi(velParts[0]), common.MustAtoi(velParts[1])}
-
-		pi.Robots = append(pi.Robots, Robot{pos, vel})
+		vel, err := parsePair(fields[1], "v=")
+		if err != nil {
+			return pi, err
+		}
+
+		pi.Robots = append(pi.Robots, Robot{
+			Position: room.Wrap(common.Point{Row: pos[1], Col: pos[0]}),
+			Velocity: common.Point{Row: vel[1], Col: vel[0]},
+		})
 	}
 
-	return pi
+	return pi, nil
+}
+
+// parsePair parses a field like p=0,4 into its two values.
+func parsePair(field common.InputField, prefix string) ([]int, error) {
+	values, err := field.TrimPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	parts := values.Split(",")
+	if len(parts) != 2 {
+		return nil, values.Errorf("want two values, got %q", values.Text)
+	}
+	return common.Ints(parts)
 }
 
 func (pi *ProblemInput) RobotsOnDifferentPositions() bool {
-	positions := make(map[Point]bool)
+	positions := make(map[common.Point]bool)
 	for _, robot := range pi.Robots {
 		if _, ok := positions[robot.Position]; ok {
 			return false
@@ -205,13 +191,12 @@ func (pi *ProblemInput) RobotsOnDifferentPositions() bool {
 	return true
 }
 
-func (pi *ProblemInput) ComputerCenterOfMass() Point {
-	centerOfMass := Point{0, 0}
+func (pi *ProblemInput) ComputerCenterOfMass() common.Point {
+	var centerOfMass common.Point
 	for _, robot := range pi.Robots {
-		centerOfMass.X += robot.Position.X
-		centerOfMass.Y += robot.Position.Y
+		centerOfMass = centerOfMass.Add(robot.Position)
 	}
-	centerOfMass.X /= len(pi.Robots)
-	centerOfMass.Y /= len(pi.Robots)
+	centerOfMass.Row /= len(pi.Robots)
+	centerOfMass.Col /= len(pi.Robots)
 	return centerOfMass
 }
@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	_ "embed"
@@ -20,12 +20,31 @@ func Test_y2024d14(t *testing.T) {
 				Input: exampleInput,
 				Part:  1,
 				Want:  "12",
+				Params: map[string]string{
+					"width":  "11",
+					"height": "7",
+				},
 			},
+			// The example has no tree, part 2 is only checked on the real
+			// input through answers.txt.
+		},
+	)
+}
+
+func Benchmark_y2024d14(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
 			{
-				Name:  "provided-example",
-				Input: exampleInput,
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
 				Part:  2,
-				Want:  "",
 			},
 		},
 	)
@@ -1,9 +1,8 @@
-package main
+package day15
 
 import (
 	_ "embed"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 15, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,53 +23,41 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
-	problemInput.PrettyPrint()
+	common.Debugf("Before moving:\n%s", problemInput)
 	problemInput.ApplyMoveSequence()
-	println()
-	println()
-	problemInput.PrettyPrint()
-	return strconv.Itoa(problemInput.SumBoxGPSValues())
+	common.Debugf("After moving:\n%s", problemInput)
+	return common.IntAnswer(problemInput.SumBoxGPSValues())
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	problemInput := parseInput(input)
 	pi2 := FromProblemInput(problemInput)
-	pi2.PrettyPrint()
+	common.Debugf("Before moving:\n%s", pi2)
 	pi2.ApplyMoveSequence()
-	println()
-	println()
-	pi2.PrettyPrint()
-	return strconv.Itoa(pi2.SumBoxGPSValues())
-}
-
-type Point struct {
-	Row, Col int
+	common.Debugf("After moving:\n%s", pi2)
+	return common.IntAnswer(pi2.SumBoxGPSValues())
 }
 
 type ProblemInput struct {
-	Grid          [][]rune
-	RobotPosition Point
+	Grid          *common.Grid[rune]
+	RobotPosition common.Point
 
-	MoveSequence []rune
+	MoveSequence []common.Direction
 }
 
 type ProblemInputPart2 struct {
-	Grid          [][]rune
-	RobotPosition Point
+	Grid          *common.Grid[rune]
+	RobotPosition common.Point
 
-	MoveSequence []rune
+	MoveSequence []common.Direction
 }
 
 func (pi *ProblemInputPart2) SumBoxGPSValues() int {
 	sum := 0
-	for r, row := range pi.Grid {
-		for c, val := range row {
-			if val == '[' {
-				sum += 100*r + c
-			}
-		}
+	for _, box := range pi.Grid.FindAll('[') {
+		sum += 100*box.Row + box.Col
 	}
 	return sum
 }
@@ -87,90 +68,82 @@ func (pi *ProblemInputPart2) ApplyMoveSequence() {
 	}
 }
 
-func (pi *ProblemInputPart2) ApplyMove(moveType rune) {
-	// < V ^ >
-	moveVectors := map[rune]Point{
-		'<': {0, -1},
-		'v': {1, 0},
-		'^': {-1, 0},
-		'>': {0, 1},
-	}
-	if _, ok := moveVectors[moveType]; !ok {
-		panic(fmt.Sprintf("Invalid move: %v", moveType))
-	}
-	move := moveVectors[moveType]
-	if pi.CanMakeMove(pi.RobotPosition, move, moveType) {
-		pi.MakeMove(pi.RobotPosition, move, moveType)
-		pi.RobotPosition = Point{pi.RobotPosition.Row + move.Row, pi.RobotPosition.Col + move.Col}
+func (pi *ProblemInputPart2) ApplyMove(move common.Direction) {
+	if pi.CanMakeMove(pi.RobotPosition, move) {
+		pi.MakeMove(pi.RobotPosition, move)
+		pi.RobotPosition = pi.RobotPosition.Add(move.Delta())
 	}
 }
 
-func (pi *ProblemInputPart2) MakeMove(start Point, move Point, moveType rune) {
-	isHorizontal := moveType == '<' || moveType == '>'
+func (pi *ProblemInputPart2) MakeMove(start common.Point, move common.Direction) {
+	isHorizontal := move == common.Left || move == common.Right
 
-	nextIsLeftSide := pi.Grid[start.Row+move.Row][start.Col+move.Col] == '['
-	nextIsRightSide := pi.Grid[start.Row+move.Row][start.Col+move.Col] == ']'
+	next := start.Add(move.Delta())
+	nextIsLeftSide := pi.Grid.At(next) == '['
+	nextIsRightSide := pi.Grid.At(next) == ']'
 
 	if isHorizontal {
 		// easy
 
 		// Move the robot pushing boxes if necessary
 		if nextIsLeftSide || nextIsRightSide {
-			nextStart := Point{start.Row + move.Row, start.Col + move.Col}
-			pi.MakeMove(nextStart, move, moveType)
+			nextStart := next
+			pi.MakeMove(nextStart, move)
 		}
 	} else {
 		// need to move both sides
 		if nextIsLeftSide {
-			nextLeftStart := Point{start.Row + move.Row, start.Col + move.Col}
-			nextRightStart := Point{start.Row + move.Row, start.Col + move.Col + 1}
-			pi.MakeMove(nextLeftStart, move, moveType)
-			pi.MakeMove(nextRightStart, move, moveType)
+			nextLeftStart := next
+			nextRightStart := next.Add(common.Right.Delta())
+			pi.MakeMove(nextLeftStart, move)
+			pi.MakeMove(nextRightStart, move)
 		} else if nextIsRightSide {
-			nextRightStart := Point{start.Row + move.Row, start.Col + move.Col}
-			nextLeftStart := Point{start.Row + move.Row, start.Col + move.Col - 1}
-			pi.MakeMove(nextRightStart, move, moveType)
-			pi.MakeMove(nextLeftStart, move, moveType)
+			nextRightStart := next
+			nextLeftStart := next.Add(common.Left.Delta())
+			pi.MakeMove(nextRightStart, move)
+			pi.MakeMove(nextLeftStart, move)
 		}
 	}
 
 	// Move point
 	// start -> .
 	// nextStart -> state value
-	startValue := pi.Grid[start.Row][start.Col]
-	pi.Grid[start.Row][start.Col] = '.'
-	pi.Grid[start.Row+move.Row][start.Col+move.Col] = startValue
+	startValue := pi.Grid.At(start)
+	pi.Grid.Set(start, '.')
+	pi.Grid.Set(next, startValue)
 }
 
-func (pi *ProblemInputPart2) CanMakeMove(start Point, move Point, moveType rune) bool {
+func (pi *ProblemInputPart2) CanMakeMove(start common.Point, move common.Direction) bool {
+	next := start.Add(move.Delta())
+
 	// if we run into a wall '#" we can't move
-	if pi.Grid[start.Row+move.Row][start.Col+move.Col] == '#' {
+	if pi.Grid.At(next) == '#' {
 		return false
 	}
 
-	nextIsLeftSide := pi.Grid[start.Row+move.Row][start.Col+move.Col] == '['
-	nextIsRightSide := pi.Grid[start.Row+move.Row][start.Col+move.Col] == ']'
+	nextIsLeftSide := pi.Grid.At(next) == '['
+	nextIsRightSide := pi.Grid.At(next) == ']'
 
-	isHorizontal := moveType == '<' || moveType == '>'
-	isVertical := moveType == '^' || moveType == 'v'
+	isHorizontal := move == common.Left || move == common.Right
+	isVertical := move == common.Up || move == common.Down
 
 	if nextIsLeftSide || nextIsRightSide {
 		if isHorizontal {
-			nextStart := Point{start.Row + move.Row, start.Col + move.Col}
-			return pi.CanMakeMove(nextStart, move, moveType)
+			nextStart := next
+			return pi.CanMakeMove(nextStart, move)
 		}
 		if isVertical {
 			// need to try moving both sides
 			if nextIsLeftSide {
-				nextLeftStart := Point{start.Row + move.Row, start.Col + move.Col}
-				nextRightStart := Point{start.Row + move.Row, start.Col + move.Col + 1}
+				nextLeftStart := next
+				nextRightStart := next.Add(common.Right.Delta())
 
-				return pi.CanMakeMove(nextLeftStart, move, moveType) && pi.CanMakeMove(nextRightStart, move, moveType)
+				return pi.CanMakeMove(nextLeftStart, move) && pi.CanMakeMove(nextRightStart, move)
 			}
 			if nextIsRightSide {
-				nextRightStart := Point{start.Row + move.Row, start.Col + move.Col}
-				nextLeftStart := Point{start.Row + move.Row, start.Col + move.Col - 1}
-				return pi.CanMakeMove(nextRightStart, move, moveType) && pi.CanMakeMove(nextLeftStart, move, moveType)
+				nextRightStart := next
+				nextLeftStart := next.Add(common.Left.Delta())
+				return pi.CanMakeMove(nextRightStart, move) && pi.CanMakeMove(nextLeftStart, move)
 			}
 		}
 
@@ -179,49 +152,34 @@ func (pi *ProblemInputPart2) CanMakeMove(start Point, move Point, moveType rune)
 	return true
 }
 
-func (pi *ProblemInputPart2) PrettyPrint() {
-	fmt.Printf("Robot at: %v\n", pi.RobotPosition)
-	for _, row := range pi.Grid {
-		fmt.Println(string(row))
-	}
+func (pi *ProblemInputPart2) String() string {
+	return fmt.Sprintf("Robot at: %v\n%s", pi.RobotPosition, pi.Grid)
 }
 
 func FromProblemInput(pi *ProblemInput) *ProblemInputPart2 {
 	// The grid is twice as wide
 	pi2 := &ProblemInputPart2{
-		Grid: make([][]rune, 0),
+		Grid: common.NewGrid[rune](pi.Grid.Rows, pi.Grid.Cols*2),
 
 		MoveSequence: pi.MoveSequence,
 	}
 
-	for r, row := range pi.Grid {
-		pi2.Grid = append(pi2.Grid, make([]rune, 0))
-		for _, val := range row {
-			if val == '#' {
-				pi2.Grid[r] = append(pi2.Grid[r], '#', '#')
-			} else if val == 'O' {
-				pi2.Grid[r] = append(pi2.Grid[r], '[', ']')
-			} else if val == '.' {
-				pi2.Grid[r] = append(pi2.Grid[r], '.', '.')
-			} else if val == '@' {
-				pi2.Grid[r] = append(pi2.Grid[r], val, '.')
-			}
-		}
+	widened := map[rune]string{'#': "##", 'O': "[]", '.': "..", '@': "@."}
+	for _, p := range pi.Grid.Points() {
+		wide := []rune(widened[pi.Grid.At(p)])
+		pi2.Grid.Set(common.Point{Row: p.Row, Col: p.Col * 2}, wide[0])
+		pi2.Grid.Set(common.Point{Row: p.Row, Col: p.Col*2 + 1}, wide[1])
 	}
 
-	pi2.RobotPosition = Point{pi.RobotPosition.Row, pi.RobotPosition.Col * 2}
+	pi2.RobotPosition = common.Point{Row: pi.RobotPosition.Row, Col: pi.RobotPosition.Col * 2}
 
 	return pi2
 }
 
 func (pi *ProblemInput) SumBoxGPSValues() int {
 	sum := 0
-	for r, row := range pi.Grid {
-		for c, val := range row {
-			if val == 'O' {
-				sum += 100*r + c
-			}
-		}
+	for _, box := range pi.Grid.FindAll('O') {
+		sum += 100*box.Row + box.Col
 	}
 	return sum
 }
@@ -232,89 +190,66 @@ func (pi *ProblemInput) ApplyMoveSequence() {
 	}
 }
 
-func (pi *ProblemInput) ApplyMove(moveType rune) {
-	// < V ^ >
-	moveVectors := map[rune]Point{
-		'<': {0, -1},
-		'v': {1, 0},
-		'^': {-1, 0},
-		'>': {0, 1},
-	}
-	if _, ok := moveVectors[moveType]; !ok {
-		panic(fmt.Sprintf("Invalid move: %v", moveType))
-	}
-	move := moveVectors[moveType]
+func (pi *ProblemInput) ApplyMove(move common.Direction) {
 	if pi.CanMakeMove(pi.RobotPosition, move) {
 		pi.MakeMove(pi.RobotPosition, move)
-		pi.RobotPosition = Point{pi.RobotPosition.Row + move.Row, pi.RobotPosition.Col + move.Col}
+		pi.RobotPosition = pi.RobotPosition.Add(move.Delta())
 	}
 }
 
-func (pi *ProblemInput) MakeMove(start Point, move Point) {
+func (pi *ProblemInput) MakeMove(start common.Point, move common.Direction) {
+	nextStart := start.Add(move.Delta())
+
 	// Move the robot pushing boxes if necessary
-	if pi.Grid[start.Row+move.Row][start.Col+move.Col] == 'O' {
-		nextStart := Point{start.Row + move.Row, start.Col + move.Col}
+	if pi.Grid.At(nextStart) == 'O' {
 		pi.MakeMove(nextStart, move)
 	}
 
 	// Move point
 	// start -> .
 	// nextStart -> state value
-	startValue := pi.Grid[start.Row][start.Col]
-	pi.Grid[start.Row][start.Col] = '.'
-	pi.Grid[start.Row+move.Row][start.Col+move.Col] = startValue
+	startValue := pi.Grid.At(start)
+	pi.Grid.Set(start, '.')
+	pi.Grid.Set(nextStart, startValue)
 }
 
-func (pi *ProblemInput) CanMakeMove(start Point, move Point) bool {
+func (pi *ProblemInput) CanMakeMove(start common.Point, move common.Direction) bool {
+	nextStart := start.Add(move.Delta())
+
 	// if we run into a wall '#" we can't move
-	if pi.Grid[start.Row+move.Row][start.Col+move.Col] == '#' {
+	if pi.Grid.At(nextStart) == '#' {
 		return false
 	}
 
 	// if we run into a box 'O' we try to push the box
 	// if the box runs into a wall we can't move
 	// if the box runs into another box ....
-	if pi.Grid[start.Row+move.Row][start.Col+move.Col] == 'O' {
-		nextStart := Point{start.Row + move.Row, start.Col + move.Col}
+	if pi.Grid.At(nextStart) == 'O' {
 		return pi.CanMakeMove(nextStart, move)
 	}
 
 	return true
 }
 
-func (pi *ProblemInput) PrettyPrint() {
-	fmt.Printf("Robot at: %v\n", pi.RobotPosition)
-	for _, row := range pi.Grid {
-		fmt.Println(string(row))
-	}
+func (pi *ProblemInput) String() string {
+	return fmt.Sprintf("Robot at: %v\n%s", pi.RobotPosition, pi.Grid)
 }
 
 func parseInput(input string) *ProblemInput {
-	pi := &ProblemInput{
-		Grid: make([][]rune, 0),
+	defer common.TimeParsing()()
 
-		MoveSequence: []rune{},
-	}
+	warehouse, moves, _ := strings.Cut(input, "\n\n")
 
-	gridMode := true
-	for r, line := range common.ReadAsLines(input) {
-		// empty line
-		if len(strings.TrimSpace(line)) == 0 {
-			gridMode = false
-			continue
-		}
+	pi := &ProblemInput{
+		Grid: common.MustParseGrid(warehouse),
+	}
+	pi.RobotPosition, _ = pi.Grid.Find('@')
 
-		if gridMode {
-			pi.Grid = append(pi.Grid, []rune(line))
-			for c, el := range line {
-				if el == '@' {
-					pi.RobotPosition = Point{r, c}
-				}
-			}
-		} else {
-			for _, r := range line {
-				pi.MoveSequence = append(pi.MoveSequence, r)
-			}
+	for _, line := range common.ReadAsLines(moves) {
+		for _, r := range strings.TrimSpace(line) {
+			move, err := common.ParseDirection(r)
+			common.CheckErr(err, "Failed to parse moves")
+			pi.MoveSequence = append(pi.MoveSequence, move)
 		}
 	}
 
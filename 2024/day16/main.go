@@ -1,9 +1,9 @@
-package main
+package day16
 
 import (
+	"context"
 	_ "embed"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,15 +13,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 16, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,16 +24,26 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
 	score := problemInput.Solve()
-	return strconv.Itoa(score)
+	return common.IntAnswer(score)
+}
+
+func (s *solver) SolvePart2(input string) common.Answer {
+	return s.SolvePart2Context(context.Background(), input)
+}
+
+func (s *solver) SolvePart1Context(ctx context.Context, input string) common.Answer {
+	return s.SolvePart1(input)
 }
 
-func (s *solver) SolvePart2(input string) string {
+// SolvePart2Context stops searching once ctx is done, the search takes
+// minutes on the real input.
+func (s *solver) SolvePart2Context(ctx context.Context, input string) common.Answer {
 	problemInput := parseInput(input)
-	score := problemInput.Solve2()
-	return strconv.Itoa(score)
+	score := problemInput.Solve2(ctx)
+	return common.IntAnswer(score)
 }
 
 const (
@@ -48,36 +52,23 @@ const (
 	PENALTY      = 100000000000
 )
 
-type Point struct {
-	Row, Col int
-}
-
-func (p *Point) Equal(other Point) bool {
-	return p.Row == other.Row && p.Col == other.Col
-}
-
-type Position struct {
-	Point     Point
-	Direction int // 0 = up, 1 = right, 2 = down, 3 = left
-}
-
 type ProblemInput struct {
-	Maze [][]rune
+	Maze *common.Grid[rune]
 
-	Start Position
-	Goal  Point
+	Start common.Pose
+	Goal  common.Point
 }
 
 type QueueItem struct {
-	Position Position
+	Position common.Pose
 	Score    int
-	Path     []Point
+	Path     []common.Point
 }
 
 func (pi *ProblemInput) Solve() int {
 	queue := make([]QueueItem, 0)
 	queue = append(queue, QueueItem{Position: pi.Start, Score: 0})
-	visited := make(map[Position]bool)
+	visited := make(map[common.Pose]bool)
 
 	for len(queue) > 0 {
 		// pop lowest score
@@ -88,7 +79,7 @@ func (pi *ProblemInput) Solve() int {
 		current := queue[0]
 		queue = queue[1:]
 
-		if pi.Goal.Equal(current.Position.Point) {
+		if pi.Goal == current.Position.Point {
 			return current.Score
 		}
 		if _, ok := visited[current.Position]; ok {
@@ -105,15 +96,19 @@ func (pi *ProblemInput) Solve() int {
 	return -1
 }
 
-func (pi *ProblemInput) Solve2() int {
+func (pi *ProblemInput) Solve2(ctx context.Context) int {
 	queue := make([]QueueItem, 0)
-	queue = append(queue, QueueItem{Position: pi.Start, Score: 0, Path: []Point{pi.Start.Point}})
-	visited := make(map[Position]int)
+	queue = append(queue, QueueItem{Position: pi.Start, Score: 0, Path: []common.Point{pi.Start.Point}})
+	visited := make(map[common.Pose]int)
 	targetScore := pi.Solve()
-	optimalPoints := make(map[Point]bool)
+	optimalPoints := make(map[common.Point]bool)
 	pointCount := 0
 
 	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			return -1
+		}
+
 		// pop lowest score
 		sort.Slice(queue, func(i, j int) bool {
 			return queue[i].Score < queue[j].Score
@@ -131,7 +126,7 @@ func (pi *ProblemInput) Solve2() int {
 		}
 		visited[current.Position] = current.Score
 
-		if pi.Goal.Equal(current.Position.Point) && current.Score == targetScore {
+		if pi.Goal == current.Position.Point && current.Score == targetScore {
 			for _, point := range current.Path {
 				if _, ok := optimalPoints[point]; !ok {
 					optimalPoints[point] = true
@@ -146,7 +141,7 @@ func (pi *ProblemInput) Solve2() int {
 			if next.Direction != current.Position.Direction {
 				queue = append(queue, QueueItem{Position: next, Score: current.Score + cost, Path: current.Path})
 			} else {
-				newPath := make([]Point, len(current.Path))
+				newPath := make([]common.Point, len(current.Path))
 				copy(newPath, current.Path)
 				newPath = append(newPath, next.Point)
 				queue = append(queue, QueueItem{Position: next, Score: current.Score + cost, Path: newPath})
@@ -158,66 +153,33 @@ func (pi *ProblemInput) Solve2() int {
 	return pointCount
 }
 
-func (pi *ProblemInput) PossibleMoves(from Position) map[Position]int {
-	nextPositionToCost := make(map[Position]int)
-	// Move forward along current direction
-	// Direction int // 0 = up, 1 = right, 2 = down, 3 = left
-	newPoint := from.Point
-	switch from.Direction {
-	case 0:
-		newPoint.Row--
-	case 1:
-		newPoint.Col++
-	case 2:
-		newPoint.Row++
-	case 3:
-		newPoint.Col--
-	}
+func (pi *ProblemInput) PossibleMoves(from common.Pose) map[common.Pose]int {
+	nextPositionToCost := make(map[common.Pose]int)
 
-	// If we remain in the maze and don't hit a wall
-	if pi.IsInMaze(newPoint) && pi.Maze[newPoint.Row][newPoint.Col] != '#' {
-		nextPositionToCost[Position{Point: newPoint, Direction: from.Direction}] = MOVE_COST
+	// Move forward along current direction, if we remain in the maze and
+	// don't hit a wall
+	if forward := from.Step(); pi.Maze.InBounds(forward.Point) && pi.Maze.At(forward.Point) != '#' {
+		nextPositionToCost[forward] = MOVE_COST
 	}
 
 	// Turn 90 degrees in either direction
-	for _, turn := range []int{1, -1} {
-		newDirection := (from.Direction + turn) % 4
-		if newDirection < 0 {
-			newDirection += 4
-		}
-
-		nextPositionToCost[Position{Point: from.Point, Direction: newDirection}] = TURN_90_COST
-	}
+	nextPositionToCost[from.TurnRight()] = TURN_90_COST
+	nextPositionToCost[from.TurnLeft()] = TURN_90_COST
 
 	return nextPositionToCost
 }
 
-func (pi *ProblemInput) IsInMaze(p Point) bool {
-	return p.Row >= 0 && p.Row < len(pi.Maze) && p.Col >= 0 && p.Col < len(pi.Maze[0])
-}
-
 func parseInput(input string) *ProblemInput {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
+	defer common.TimeParsing()()
 
 	pi := &ProblemInput{
-		Maze: make([][]rune, len(lines)),
-		Start: Position{
-			Direction: 1, // always start facing right
+		Maze: common.MustParseGrid(input),
+		Start: common.Pose{
+			Direction: common.Right, // always start facing right
 		},
 	}
-
-	for i, line := range lines {
-		pi.Maze[i] = []rune(line)
-		for j, element := range line {
-			if element == 'S' {
-				pi.Start.Point = Point{Row: i, Col: j}
-			}
-
-			if element == 'E' {
-				pi.Goal = Point{Row: i, Col: j}
-			}
-		}
-	}
+	pi.Start.Point, _ = pi.Maze.Find('S')
+	pi.Goal, _ = pi.Maze.Find('E')
 
 	return pi
 }
@@ -1,4 +1,4 @@
-package main
+package day16
 
 import (
 	_ "embed"
@@ -30,3 +30,22 @@ func Test_y2024d16(t *testing.T) {
 		},
 	)
 }
+
+func Benchmark_y2024d16(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
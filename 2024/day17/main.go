@@ -1,9 +1,8 @@
-package main
+package day17
 
 import (
 	_ "embed"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,15 +12,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 17, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -30,25 +23,17 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	problemInput := parseInput(input)
-	problemInput.PrettyPrint()
+	common.Debugf("Parsed:\n%s", problemInput)
 	outputs := problemInput.Run()
-	commaSeperateStr := ""
-	for i, output := range outputs {
-		if i > 0 {
-			commaSeperateStr += ","
-		}
-		commaSeperateStr += strconv.Itoa(output)
-	}
-
-	return commaSeperateStr
+	return common.IntsAnswer(outputs)
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	pi := parseInput(input)
 	result := pi.RecoverCorruptedRegisterASmart()
-	return strconv.Itoa(result)
+	return common.IntAnswer(result)
 }
 
 type Instruction struct {
@@ -191,20 +176,23 @@ func (pi *ProblemInput) Run() []int {
 	return outputs
 }
 
-func (pi *ProblemInput) PrettyPrint() {
-	fmt.Printf("Register A: %d\n", pi.RegisterA)
-	fmt.Printf("Register B: %d\n", pi.RegisterB)
-	fmt.Printf("Register C: %d\n", pi.RegisterC)
-	fmt.Printf("Program: %v\n", pi.Program)
+func (pi *ProblemInput) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Register A: %d\n", pi.RegisterA)
+	fmt.Fprintf(&sb, "Register B: %d\n", pi.RegisterB)
+	fmt.Fprintf(&sb, "Register C: %d\n", pi.RegisterC)
+	fmt.Fprintf(&sb, "Program: %v\n", pi.Program)
+	return sb.String()
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := common.ReadAsLines(input)
 	pi := &ProblemInput{
 		Program: make([]Instruction, 0),
 	}
 	for _, line := range lines {
-		println(line)
 		if len(strings.TrimSpace(line)) == 0 {
 			continue
 		}
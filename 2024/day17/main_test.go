@@ -1,4 +1,4 @@
-package main
+package day17
 
 import (
 	_ "embed"
@@ -10,8 +10,8 @@ import (
 //go:embed example_input.txt
 var exampleInput string
 
-//go:embed example_input2.txt
-var exampleInput2 string
+//go:embed example_part2_input.txt
+var examplePart2Input string
 
 func Test_y2024d17(t *testing.T) {
 	common.RunTests(
@@ -26,10 +26,29 @@ func Test_y2024d17(t *testing.T) {
 			},
 			{
 				Name:  "provided-example",
-				Input: exampleInput2,
+				Input: examplePart2Input,
 				Part:  2,
 				Want:  "117440",
 			},
 		},
 	)
 }
+
+func Benchmark_y2024d17(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
+	)
+}
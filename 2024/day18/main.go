@@ -1,9 +1,8 @@
-package main
+package day18
 
 import (
 	_ "embed"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -13,40 +12,47 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 18, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
 // Solution
 //--------------------------------------------------------------------
 
-type solver struct{}
+type solver struct {
+	params common.Params
+}
 
-func (s *solver) SolvePart1(input string) string {
-	problemInput := parseInput(input)
-	steps := 12
-	if len(problemInput.Obstacles) > 30 {
-		steps = 1024
+func (s *solver) Params() []common.Param {
+	return []common.Param{
+		{Name: "size", Default: "70", Usage: "coordinate of the exit in both directions, 6 in the example"},
+		{Name: "bytes", Default: "1024", Usage: "bytes fallen in part 1, 12 in the example"},
 	}
+}
+
+func (s *solver) WithParams(params common.Params) common.ProblemSolver {
+	return &solver{params: params}
+}
+
+func (s *solver) SolvePart1(input string) common.Answer {
+	params := common.ParamsOrDefault(s, s.params)
+	problemInput := parseInput(input, params.Int("size"))
+	steps := params.Int("bytes")
 	shortestLen, path := problemInput.FindShortestPath(steps)
-	problemInput.PrettyPrint(steps, path)
-	println()
-	problemInput.PrettyPrint(steps, nil)
-	return strconv.Itoa(shortestLen)
+	if common.LogEnabled(common.LevelDebug) {
+		common.Debugf("Shortest path:\n%s", problemInput.Render(steps, path))
+		common.Debugf("Memory space:\n%s", problemInput.Render(steps, nil))
+	}
+	return common.IntAnswer(shortestLen)
 }
 
-func (s *solver) SolvePart2(input string) string {
-	problemInput := parseInput(input)
+func (s *solver) SolvePart2(input string) common.Answer {
+	params := common.ParamsOrDefault(s, s.params)
+	problemInput := parseInput(input, params.Int("size"))
 	p := problemInput.FindUnreachableStep()
-	return p.String()
+	return common.StringAnswer(p.String())
 }
 
 //--------------------------------------------------------------------
@@ -71,7 +77,7 @@ func (pi *ProblemInput) FindUnreachableStep() Point {
 	maxStep := len(pi.Obstacles) - 1
 	for step := 0; step < maxStep; step++ {
 		shortestLen, _ := pi.FindShortestPath(step)
-		println(step, shortestLen)
+		common.Debugf("%d bytes fallen, shortest path %d", step, shortestLen)
 		if shortestLen == -1 {
 			return pi.Obstacles[step-1]
 
@@ -81,33 +87,36 @@ func (pi *ProblemInput) FindUnreachableStep() Point {
 
 }
 
-func (pi *ProblemInput) PrettyPrint(maxObstacleIdx int, path []Point) {
+// Render draws the memory space with the first maxObstacleIdx bytes fallen
+// and path marked.
+func (pi *ProblemInput) Render(maxObstacleIdx int, path []Point) string {
 	pathMap := make(map[Point]bool)
 	for _, p := range path {
 		pathMap[p] = true
 	}
+	var sb strings.Builder
 	for x := 0; x <= pi.Exit.X; x++ {
 		for y := 0; y <= pi.Exit.Y; y++ {
 			p := Point{x, y}
 			if p == pi.Start {
-				print("O")
+				sb.WriteString("O")
 			} else if p == pi.Exit {
-				print("O")
+				sb.WriteString("O")
 			} else if _, ok := pathMap[p]; ok {
-				print("O")
+				sb.WriteString("O")
 			} else if idx, ok := pi.ObstacleMap[p]; ok {
 				if idx < maxObstacleIdx {
-					print("#")
+					sb.WriteString("#")
 				} else {
-					print(".")
+					sb.WriteString(".")
 				}
 			} else {
-				print(".")
+				sb.WriteString(".")
 			}
 		}
-		println()
+		sb.WriteString("\n")
 	}
-	println()
+	return sb.String()
 }
 
 func (pi *ProblemInput) FindShortestPath(maxObstacleIdx int) (int, []Point) {
@@ -163,23 +172,16 @@ func (pi *ProblemInput) FindShortestPath(maxObstacleIdx int) (int, []Point) {
 	return -1, nil
 }
 
-var (
-	ExampleExit = Point{6, 6}
-	RealExit    = Point{70, 70}
-)
+func parseInput(input string, size int) ProblemInput {
+	defer common.TimeParsing()()
 
-func parseInput(input string) ProblemInput {
 	obstactleStrs := common.ReadAsLines(input)
 
 	pi := ProblemInput{
 		Start:       Point{0, 0},
 		Obstacles:   make([]Point, 0),
 		ObstacleMap: make(map[Point]int),
-		Exit:        ExampleExit,
-	}
-
-	if len(obstactleStrs) > 30 {
-		pi.Exit = RealExit
+		Exit:        Point{size, size},
 	}
 
 	for i, obsStr := range obstactleStrs {
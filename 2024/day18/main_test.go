@@ -1,4 +1,4 @@
-package main
+package day18
 
 import (
 	_ "embed"
@@ -20,12 +20,38 @@ func Test_y2024d18(t *testing.T) {
 				Input: exampleInput,
 				Part:  1,
 				Want:  "22",
+				Params: map[string]string{
+					"size":  "6",
+					"bytes": "12",
+				},
 			},
 			{
 				Name:  "provided-example",
 				Input: exampleInput,
 				Part:  2,
 				Want:  "6,1",
+				Params: map[string]string{
+					"size": "6",
+				},
+			},
+		},
+	)
+}
+
+func Benchmark_y2024d18(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
 			},
 		},
 	)
@@ -1,4 +1,4 @@
-package main
+package day19
 
 import (
 	_ "embed"
@@ -11,15 +11,9 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 19, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
@@ -28,14 +22,14 @@ func main() {
 
 type solver struct{}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) SolvePart1(input string) common.Answer {
 	pi := parseInput(input)
-	return common.Itoa(pi.CountPossibleTowels())
+	return common.IntAnswer(pi.CountPossibleTowels())
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
 	pi := parseInput(input)
-	return common.Itoa(pi.NumPossibleWaysToMakeTowels())
+	return common.IntAnswer(pi.NumPossibleWaysToMakeTowels())
 }
 
 type Pattern = []rune
@@ -172,6 +166,8 @@ func (pi *ProblemInput) NumPossibleWaysToMakeTowels() int {
 }
 
 func parseInput(input string) *ProblemInput {
+	defer common.TimeParsing()()
+
 	lines := common.ReadAsLines(input)
 
 	pi := &ProblemInput{
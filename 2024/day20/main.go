@@ -1,8 +1,7 @@
-package main
+package day20
 
 import (
 	_ "embed"
-	"image"
 	"strings"
 
 	"github.com/jhh3/aoc/common"
@@ -12,53 +11,53 @@ import (
 var input string
 
 func init() {
-	// do this in init (not main) so test file has same input
+	// do this in init so test file has same input
 	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	common.RunFromSolver(&solver{}, input)
+	common.Register(2024, 20, &solver{}, input)
 }
 
 //--------------------------------------------------------------------
 // Solution
 //--------------------------------------------------------------------
 
-type solver struct{}
+type solver struct {
+	params common.Params
+}
+
+func (s *solver) Params() []common.Param {
+	return []common.Param{
+		{Name: "save", Default: "100", Usage: "picoseconds a cheat must save at least"},
+	}
+}
 
-func (s *solver) SolvePart1(input string) string {
+func (s *solver) WithParams(params common.Params) common.ProblemSolver {
+	return &solver{params: params}
+}
+
+func (s *solver) SolvePart1(input string) common.Answer {
+	params := common.ParamsOrDefault(s, s.params)
 	problemInput := parseInput(input)
-	return common.Itoa(problemInput.CountCheats(2, 100))
+	return common.IntAnswer(problemInput.CountCheats(2, params.Int("save")))
 }
 
-func (s *solver) SolvePart2(input string) string {
+func (s *solver) SolvePart2(input string) common.Answer {
+	params := common.ParamsOrDefault(s, s.params)
 	problemInput := parseInput(input)
-	return common.Itoa(problemInput.CountCheats(20, 100))
+	return common.IntAnswer(problemInput.CountCheats(20, params.Int("save")))
 }
 
 type ProblemInput struct {
-	Grid  map[image.Point]rune
-	Start image.Point
-	End   image.Point
+	Grid  *common.Grid[rune]
+	Start common.Point
+	End   common.Point
 }
 
 func parseInput(input string) *ProblemInput {
-	grid, start, end := map[image.Point]rune{}, image.Point{}, image.Point{}
-	for y, s := range strings.Fields(input) {
-		for x, r := range s {
-			if r == 'S' {
-				start = image.Point{x, y}
-			}
-			if r == 'E' {
-				end = image.Point{x, y}
-			}
+	defer common.TimeParsing()()
 
-			grid[image.Point{x, y}] = r
-		}
-	}
+	grid := common.MustParseGrid(input)
+	start, _ := grid.Find('S')
+	end, _ := grid.Find('E')
 	return &ProblemInput{
 		Grid:  grid,
 		Start: start,
@@ -68,13 +67,12 @@ func parseInput(input string) *ProblemInput {
 
 func (pi *ProblemInput) CountCheats(cheatDistance, goalTimeSave int) int {
 	// Get distance from start to all points
-	queue, dist := []image.Point{pi.Start}, map[image.Point]int{pi.Start: 0}
+	queue, dist := []common.Point{pi.Start}, map[common.Point]int{pi.Start: 0}
 	for len(queue) > 0 {
 		p := queue[0]
 		queue = queue[1:]
-		for _, d := range []image.Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
-			n := p.Add(d)
-			if _, ok := dist[n]; !ok && pi.Grid[n] != '#' {
+		for _, n := range pi.Grid.Neighbors4(p) {
+			if _, ok := dist[n]; !ok && pi.Grid.At(n) != '#' {
 				queue, dist[n] = append(queue, n), dist[p]+1
 			}
 		}
@@ -84,9 +82,8 @@ func (pi *ProblemInput) CountCheats(cheatDistance, goalTimeSave int) int {
 	// if we were to cheat
 	count := 0
 	for p1 := range dist {
-		for p2 := range dist {
-			d := common.AbsInt(p2.X-p1.X) + common.AbsInt(p2.Y-p1.Y)
-			if d <= cheatDistance && dist[p2] >= dist[p1]+d+goalTimeSave {
+		for _, p2 := range p1.Within(cheatDistance) {
+			if d2, ok := dist[p2]; ok && d2 >= dist[p1]+p1.Manhattan(p2)+goalTimeSave {
 				count++
 			}
 		}
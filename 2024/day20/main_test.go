@@ -1,4 +1,4 @@
-package main
+package day20
 
 import (
 	_ "embed"
@@ -14,6 +14,40 @@ func Test_y2024d20(t *testing.T) {
 	common.RunTests(
 		&solver{},
 		t,
-		[]common.Test{},
+		[]common.Test{
+			{
+				Name:   "provided-example",
+				Input:  exampleInput,
+				Part:   1,
+				Want:   "5",
+				Params: map[string]string{"save": "20"},
+			},
+			{
+				Name:   "provided-example",
+				Input:  exampleInput,
+				Part:   2,
+				Want:   "285",
+				Params: map[string]string{"save": "50"},
+			},
+		},
+	)
+}
+
+func Benchmark_y2024d20(b *testing.B) {
+	common.RunBenchmarks(
+		&solver{},
+		b,
+		[]common.Benchmark{
+			{
+				Name:  "input",
+				Input: input,
+				Part:  1,
+			},
+			{
+				Name:  "input",
+				Input: input,
+				Part:  2,
+			},
+		},
 	)
 }
@@ -0,0 +1,278 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// AnswerKind tells how an answer is normalized and compared.
+type AnswerKind int
+
+const (
+	KindString AnswerKind = iota
+	KindInt
+	KindList
+	KindArt
+)
+
+func (k AnswerKind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindList:
+		return "list"
+	case KindArt:
+		return "art"
+	default:
+		return "string"
+	}
+}
+
+func (k AnswerKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Answer is the result of solving a part. It is built with one of the
+// constructors below, which normalize the value so answers computed,
+// expected in tests, recorded in answers.txt and submitted all compare the
+// same way.
+type Answer struct {
+	Kind AnswerKind
+	// text is the normalized value.
+	text string
+	// Diagnostics are extra lines about how the answer was found, printed
+	// by the runner and logged by tests.
+	Diagnostics []string
+}
+
+// IntAnswer is an integer answer, the most common kind.
+func IntAnswer(n int) Answer {
+	return Answer{Kind: KindInt, text: strconv.Itoa(n)}
+}
+
+// Int64Answer is an integer answer too large for an int on some platforms.
+func Int64Answer(n int64) Answer {
+	return Answer{Kind: KindInt, text: strconv.FormatInt(n, 10)}
+}
+
+// BigIntAnswer is an integer answer too large for an int64.
+func BigIntAnswer(n *big.Int) Answer {
+	return Answer{Kind: KindInt, text: n.String()}
+}
+
+// StringAnswer is an answer used as is, but for surrounding whitespace.
+func StringAnswer(s string) Answer {
+	return Answer{Kind: KindString, text: normalizeAnswer(KindString, s)}
+}
+
+// IntsAnswer is a list of integers, written comma separated as the puzzles
+// ask for them.
+func IntsAnswer(values []int) Answer {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = strconv.Itoa(v)
+	}
+	return Answer{Kind: KindList, text: strings.Join(items, ",")}
+}
+
+// ArtAnswer is a picture to read letters from, like the ones drawn by
+// pixels on a screen. Any character but '.' and ' ' is a lit pixel.
+func ArtAnswer(art string) Answer {
+	return Answer{Kind: KindArt, text: normalizeAnswer(KindArt, art)}
+}
+
+// WithDiagnostic returns the answer with one more diagnostic line.
+func (a Answer) WithDiagnostic(format string, args ...any) Answer {
+	a.Diagnostics = append(append([]string(nil), a.Diagnostics...), fmt.Sprintf(format, args...))
+	return a
+}
+
+// String returns the normalized answer, several lines for art.
+func (a Answer) String() string {
+	return a.text
+}
+
+// Submission returns the answer as it is typed on the site. Art is read
+// into letters, which fails for glyphs it does not know.
+func (a Answer) Submission() (string, error) {
+	if a.Kind != KindArt {
+		return a.text, nil
+	}
+	return readLetters(a.text)
+}
+
+// Matches reports whether want, normalized for the kind of a, is this
+// answer. Art also matches the letters it reads as.
+func (a Answer) Matches(want string) bool {
+	if normalizeAnswer(a.Kind, want) == a.text {
+		return true
+	}
+	if a.Kind == KindArt {
+		letters, err := readLetters(a.text)
+		return err == nil && letters == strings.TrimSpace(want)
+	}
+	return false
+}
+
+// normalizeAnswer applies the rules of kind to s:
+//   - strings drop surrounding whitespace
+//   - integers also drop a leading + and leading zeros
+//   - lists also drop whitespace around items
+//   - art drops trailing whitespace and blank lines around the picture,
+//     and draws lit pixels as '#' and others as '.'
+func normalizeAnswer(kind AnswerKind, s string) string {
+	switch kind {
+	case KindInt:
+		s = strings.TrimSpace(s)
+		var n big.Int
+		if _, ok := n.SetString(strings.TrimPrefix(s, "+"), 10); ok {
+			return n.String()
+		}
+		return s
+	case KindList:
+		items := strings.Split(strings.TrimSpace(s), ",")
+		for i, item := range items {
+			items[i] = strings.TrimSpace(item)
+		}
+		return strings.Join(items, ",")
+	case KindArt:
+		return normalizeArt(s)
+	default:
+		return strings.TrimSpace(s)
+	}
+}
+
+func normalizeArt(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		lines[i] = strings.Map(func(r rune) rune {
+			if r == '.' || r == ' ' {
+				return '.'
+			}
+			return '#'
+		}, line)
+	}
+
+	blank := func(line string) bool {
+		return !strings.Contains(line, "#")
+	}
+	for len(lines) > 0 && blank(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && blank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line + strings.Repeat(".", width-len(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// letterGlyphs maps every glyph of the 4x6 font letters are drawn with to
+// its letter.
+var letterGlyphs = map[string]rune{
+	".##.\n#..#\n#..#\n####\n#..#\n#..#": 'A',
+	"###.\n#..#\n###.\n#..#\n#..#\n###.": 'B',
+	".##.\n#..#\n#...\n#...\n#..#\n.##.": 'C',
+	"####\n#...\n###.\n#...\n#...\n####": 'E',
+	"####\n#...\n###.\n#...\n#...\n#...": 'F',
+	".##.\n#..#\n#...\n#.##\n#..#\n.###": 'G',
+	"#..#\n#..#\n####\n#..#\n#..#\n#..#": 'H',
+	".###\n..#.\n..#.\n..#.\n..#.\n.###": 'I',
+	"..##\n...#\n...#\n...#\n#..#\n.##.": 'J',
+	"#..#\n#.#.\n##..\n#.#.\n#.#.\n#..#": 'K',
+	"#...\n#...\n#...\n#...\n#...\n####": 'L',
+	".##.\n#..#\n#..#\n#..#\n#..#\n.##.": 'O',
+	"###.\n#..#\n#..#\n###.\n#...\n#...": 'P',
+	"###.\n#..#\n#..#\n###.\n#.#.\n#..#": 'R',
+	".###\n#...\n#...\n.##.\n...#\n###.": 'S',
+	"#..#\n#..#\n#..#\n#..#\n#..#\n.##.": 'U',
+	"#...\n#...\n.#.#\n..#.\n..#.\n..#.": 'Y',
+	"####\n...#\n..#.\n.#..\n#...\n####": 'Z',
+}
+
+// readLetters reads normalized art drawn with letterGlyphs. Letters are
+// told apart by the blank columns between them.
+func readLetters(art string) (string, error) {
+	lines := strings.Split(art, "\n")
+	if len(lines) != 6 {
+		return "", fmt.Errorf("cannot read letters from art %d lines high, want 6", len(lines))
+	}
+
+	columnBlank := func(col int) bool {
+		for _, line := range lines {
+			if line[col] == '#' {
+				return false
+			}
+		}
+		return true
+	}
+
+	var letters strings.Builder
+	width := len(lines[0])
+	for col := 0; col < width; {
+		if columnBlank(col) {
+			col++
+			continue
+		}
+		end := col
+		for end < width && !columnBlank(end) {
+			end++
+		}
+
+		letter, ok := readGlyph(lines, col, end)
+		if !ok {
+			return "", fmt.Errorf("cannot read the letter at columns %d to %d", col, end-1)
+		}
+		letters.WriteRune(letter)
+		col = end
+	}
+	return letters.String(), nil
+}
+
+// readGlyph matches columns [from, to) of lines with the glyphs, which may
+// start or end with blank columns.
+func readGlyph(lines []string, from, to int) (rune, bool) {
+	for glyph, letter := range letterGlyphs {
+		rows := strings.Split(glyph, "\n")
+		left, right := glyphBounds(rows)
+		if right-left != to-from {
+			continue
+		}
+		match := true
+		for i, row := range rows {
+			if row[left:right] != lines[i][from:to] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return letter, true
+		}
+	}
+	return 0, false
+}
+
+// glyphBounds returns the first and past the last lit column of rows.
+func glyphBounds(rows []string) (int, int) {
+	left, right := len(rows[0]), 0
+	for _, row := range rows {
+		if i := strings.Index(row, "#"); i >= 0 && i < left {
+			left = i
+		}
+		if i := strings.LastIndex(row, "#"); i+1 > right {
+			right = i + 1
+		}
+	}
+	return left, right
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestAnswerMatches(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name   string
+		answer Answer
+		want   string
+		match  bool
+	}{
+		{"int", IntAnswer(42), " 42\n", true},
+		{"int-leading-zeros", IntAnswer(42), "+0042", true},
+		{"int-other", IntAnswer(42), "43", false},
+		{"int64", Int64Answer(875318608908), "875318608908", true},
+		{"big", BigIntAnswer(huge), "123456789012345678901234567890", true},
+		{"string", StringAnswer(" 6,1 "), "6,1", true},
+		{"list", IntsAnswer([]int{4, 6, 3}), "4, 6, 3", true},
+		{"list-other", IntsAnswer([]int{4, 6, 3}), "4,6", false},
+		{"art", ArtAnswer("\n#..#  \n####\n"), "#..#\n####", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.answer.Matches(tt.want); got != tt.match {
+				t.Errorf("%v.Matches(%q) = %v, want %v", tt.answer, tt.want, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestArtAnswerSubmission(t *testing.T) {
+	// "HI" drawn with spaces and full blocks, as a screen would.
+	art := strings.Join([]string{
+		"█  █  ███",
+		"█  █   █ ",
+		"████   █ ",
+		"█  █   █ ",
+		"█  █   █ ",
+		"█  █  ███",
+	}, "\n")
+
+	answer := ArtAnswer(art)
+	got, err := answer.Submission()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "HI" {
+		t.Errorf("Submission() = %q, want %q", got, "HI")
+	}
+	if !answer.Matches("HI") {
+		t.Error("art does not match the letters it reads as")
+	}
+
+	if _, err := ArtAnswer("#\n#").Submission(); err == nil {
+		t.Error("expected an error for art that is not letters")
+	}
+}
+
+func TestAnswerDiagnostics(t *testing.T) {
+	answer := IntAnswer(1).WithDiagnostic("took %d steps", 3)
+	if len(answer.Diagnostics) != 1 || answer.Diagnostics[0] != "took 3 steps" {
+		t.Errorf("diagnostics = %q", answer.Diagnostics)
+	}
+}
@@ -0,0 +1,172 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnswersFileName is the file next to each day's input.txt holding the
+// accepted answers, one "partN: answer" line per part. Lines starting with
+// '#' are comments.
+const AnswersFileName = "answers.txt"
+
+// Answers maps a part to its accepted answer.
+type Answers map[int]string
+
+// AnswersPath returns the answers file of a day below the module root.
+func AnswersPath(root string, year, day int) string {
+	return filepath.Join(root, fmt.Sprintf("%d/day%02d", year, day), AnswersFileName)
+}
+
+func ParseAnswers(content string) (Answers, error) {
+	answers := make(Answers)
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.HasPrefix(key, "part") {
+			return nil, fmt.Errorf("line %d: expected \"partN: answer\", got %q", i+1, line)
+		}
+		part, err := strconv.Atoi(strings.TrimPrefix(key, "part"))
+		if err != nil || (part != 1 && part != 2) {
+			return nil, fmt.Errorf("line %d: invalid part %q", i+1, key)
+		}
+		answers[part] = strings.TrimSpace(value)
+	}
+	return answers, nil
+}
+
+// ReadAnswers reads an answers file. A missing file has no answers.
+func ReadAnswers(path string) (Answers, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(Answers), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := ParseAnswers(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return answers, nil
+}
+
+func (a Answers) String() string {
+	parts := make([]int, 0, len(a))
+	for part := range a {
+		parts = append(parts, part)
+	}
+	sort.Ints(parts)
+
+	var sb strings.Builder
+	for _, part := range parts {
+		fmt.Fprintf(&sb, "part%d: %s\n", part, a[part])
+	}
+	return sb.String()
+}
+
+func (a Answers) Write(path string) error {
+	return os.WriteFile(path, []byte(a.String()), 0644)
+}
+
+// RecordAnswer writes the accepted answer of part to the answers file of a
+// day below root, keeping the answer of the other part.
+func RecordAnswer(root string, year, day, part int, answer string) error {
+	path := AnswersPath(root, year, day)
+	answers, err := ReadAnswers(path)
+	if err != nil {
+		return err
+	}
+	answers[part] = strings.TrimSpace(answer)
+	return answers.Write(path)
+}
+
+type VerifyStatus int
+
+const (
+	VerifyMissing VerifyStatus = iota
+	VerifyPass
+	VerifyFail
+)
+
+func (vs VerifyStatus) String() string {
+	switch vs {
+	case VerifyPass:
+		return "pass"
+	case VerifyFail:
+		return "FAIL"
+	default:
+		return "missing"
+	}
+}
+
+// VerifyResult is the outcome of checking one part of a solution against its
+// recorded answer.
+type VerifyResult struct {
+	Solution *Solution
+	Part     int
+	Status   VerifyStatus
+	Got      string
+	Want     string
+	Err      error
+	Duration time.Duration
+}
+
+// Verify solves a part on the embedded input and compares the result with the
+// recorded answer. Parts without a recorded answer are not solved unless
+// solveMissing is set. A part still solving when ctx is done fails with the
+// error of ctx.
+func Verify(ctx context.Context, solution *Solution, answers Answers, part int, solveMissing bool) (result VerifyResult) {
+	want, ok := answers[part]
+	result = VerifyResult{
+		Solution: solution,
+		Part:     part,
+		Status:   VerifyMissing,
+		Want:     want,
+	}
+	if !ok && !solveMissing {
+		return result
+	}
+
+	input, err := solution.EmbeddedInput()
+	if err != nil {
+		result.Status = VerifyFail
+		result.Err = err
+		return result
+	}
+
+	start := time.Now()
+	answer, err := SolveContext(ctx, solution.Solver, part, input)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Status = VerifyFail
+		result.Err = err
+		return result
+	}
+
+	result.Got = answer.String()
+	// Record what would be submitted, art does not fit on a line.
+	if submission, err := answer.Submission(); err == nil {
+		result.Got = submission
+	}
+	if ok {
+		result.Status = VerifyFail
+		if answer.Matches(want) {
+			result.Status = VerifyPass
+		}
+	}
+
+	return result
+}
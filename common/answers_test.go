@@ -0,0 +1,30 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecordAnswer(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "2024/day14"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RecordAnswer(root, 2024, 14, 2, "8050\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordAnswer(root, 2024, 14, 1, "215987200"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadAnswers(AnswersPath(root, 2024, 14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Answers{1: "215987200", 2: "8050"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadAnswers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCache is returned for cached inputs that are damaged or hold an
+// error page instead of a puzzle input.
+var ErrInvalidCache = errors.New("invalid cached input")
+
+// cacheMetadataSuffix names the sidecar written next to every cached input.
+const cacheMetadataSuffix = ".meta.json"
+
+// CacheMetadata describes where a cached input came from, so damaged caches
+// can be told apart from good ones.
+type CacheMetadata struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Url       string    `json:"url"`
+	Sha256    string    `json:"sha256"`
+	Size      int       `json:"size"`
+}
+
+// FindModuleRoot returns the closest directory at or above the working
+// directory holding a go.mod file.
+func FindModuleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("no go.mod found above the working directory")
+		}
+		dir = parent
+	}
+}
+
+// InModuleRoot returns the path of name in the module root, so files shared
+// by every day end up in one place wherever the command runs. Outside of a
+// module it is name, relative to the working directory.
+func InModuleRoot(name string) string {
+	root, err := FindModuleRoot()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(root, name)
+}
+
+// readCachedInput returns the input cached at path. It fails with
+// os.ErrNotExist when there is none and ErrInvalidCache when it does not
+// match its metadata or looks like an error page.
+func readCachedInput(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	content := string(data)
+
+	if err := validateInput(content); err != nil {
+		return "", err
+	}
+
+	// Inputs cached before metadata existed have no sidecar, the checks
+	// above are all we can do for them.
+	metaData, err := os.ReadFile(path + cacheMetadataSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return content, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var meta CacheMetadata
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return "", fmt.Errorf("%w: unreadable metadata: %v", ErrInvalidCache, err)
+	}
+	if len(data) != meta.Size {
+		return "", fmt.Errorf("%w: %d bytes, fetched %d", ErrInvalidCache, len(data), meta.Size)
+	}
+	if sum := sha256Hex(data); sum != meta.Sha256 {
+		return "", fmt.Errorf("%w: checksum %s, fetched %s", ErrInvalidCache, sum, meta.Sha256)
+	}
+
+	return content, nil
+}
+
+// writeCachedInput caches content fetched from url at path, along with its
+// metadata.
+func writeCachedInput(path string, content string, url string) error {
+	meta := CacheMetadata{
+		FetchedAt: time.Now().UTC(),
+		Url:       url,
+		Sha256:    sha256Hex([]byte(content)),
+		Size:      len(content),
+	}
+	metaData, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
+		return err
+	}
+	return writeFileAtomic(path+cacheMetadataSuffix, append(metaData, '\n'))
+}
+
+// validateInput rejects content that cannot be a puzzle input: nothing at
+// all, or an HTML page served in place of the input.
+func validateInput(content string) error {
+	trimmed := strings.TrimSpace(content)
+	lower := strings.ToLower(trimmed)
+	switch {
+	case trimmed == "":
+		return fmt.Errorf("%w: empty", ErrInvalidCache)
+	case strings.HasPrefix(lower, "<!doctype html"), strings.HasPrefix(lower, "<html"):
+		return fmt.Errorf("%w: HTML page", ErrInvalidCache)
+	case strings.HasPrefix(trimmed, "Puzzle inputs differ by user"),
+		strings.HasPrefix(trimmed, "Please don't repeatedly request this endpoint"),
+		strings.HasPrefix(trimmed, "404 Not Found"):
+		return fmt.Errorf("%w: error page %q", ErrInvalidCache, firstLine(trimmed))
+	}
+	return nil
+}
+
+// writeFileAtomic writes to a temporary file first, so an interrupted
+// write never leaves a truncated file behind. Missing directories are
+// created.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInputCache(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2024/day01/input.txt")
+	const input = "3   4\n4   3\n"
+
+	if _, err := readCachedInput(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("readCachedInput() on empty cache = %v, want %v", err, os.ErrNotExist)
+	}
+
+	if err := writeCachedInput(path, input, "https://adventofcode.com/2024/day/1/input"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readCachedInput(path); err != nil || got != input {
+		t.Fatalf("readCachedInput() = %q, %v, want %q", got, err, input)
+	}
+
+	// Truncated after it was written.
+	if err := os.WriteFile(path, []byte(input[:5]), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCachedInput(path); !errors.Is(err, ErrInvalidCache) {
+		t.Errorf("readCachedInput() of truncated cache = %v, want %v", err, ErrInvalidCache)
+	}
+
+	// Cached before metadata existed.
+	legacy := filepath.Join(dir, "2023/day01/input.txt")
+	if err := os.MkdirAll(filepath.Dir(legacy), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readCachedInput(legacy); err != nil || got != input {
+		t.Errorf("readCachedInput() of legacy cache = %q, %v, want %q", got, err, input)
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{"input", "3   4\n", true},
+		{"empty", "\n", false},
+		{"html", "<!DOCTYPE html>\n<html lang=\"en-us\">", false},
+		{"not-logged-in", "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n", false},
+		{"not-unlocked", "Please don't repeatedly request this endpoint before it unlocks! The calendar countdown is synchronized with the server time; the link will be enabled on the calendar the instant this puzzle becomes available.\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInput(tt.content)
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("validateInput() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestInModuleRoot(t *testing.T) {
+	// Tests run in the directory of the package, below the module root.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := InModuleRoot(DefaultLedgerPath), filepath.Join(filepath.Dir(wd), DefaultLedgerPath); got != want {
+		t.Errorf("InModuleRoot(%q) = %q, want %q", DefaultLedgerPath, got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultUserAgent identifies this tool, as the site asks automated clients
+// to do. Set -useragent to add contact details.
+const DefaultUserAgent = "github.com/jhh3/aoc"
+
+var (
+	// ErrNotLoggedIn is returned when the session is missing or expired.
+	ErrNotLoggedIn = errors.New("not logged in, check the session cookie")
+	// ErrNotFound is returned when the puzzle is not unlocked yet, or does
+	// not exist.
+	ErrNotFound = errors.New("not found, the puzzle may not be unlocked yet")
+	// ErrServer is returned for 5xx responses once all retries are used up.
+	ErrServer = errors.New("server error")
+)
+
+// HTTPStatusError is returned for any response other than 200 OK. It
+// unwraps to one of ErrNotLoggedIn, ErrNotFound or ErrServer when the
+// status says which.
+type HTTPStatusError struct {
+	Method     string
+	Url        string
+	StatusCode int
+	Status     string
+	// Body is the start of the response, which usually explains the error.
+	Body string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.Url, e.Status, e.Body)
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest && strings.Contains(e.Body, "log in"):
+		return ErrNotLoggedIn
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrNotLoggedIn
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// requestLimiter spaces out all requests made by this process, whichever
+// client makes them.
+var requestLimiter = &rateLimiter{}
+
+type rateLimiter struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// wait blocks until at least interval has passed since the previous call.
+func (rl *rateLimiter) wait(interval time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if wait := interval - time.Since(rl.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	rl.last = time.Now()
+}
+
+// siteClient talks to the website: it sends the session cookie and the
+// User-Agent, spaces out requests and retries server errors.
+type siteClient struct {
+	httpClient  *http.Client
+	userAgent   string
+	retries     int
+	minInterval time.Duration
+	// backoff is the wait before the first retry, doubled for every next one.
+	backoff time.Duration
+}
+
+// newSiteClient creates a client whose cookie jar holds the session cookie
+// for the site, shared by everything that talks to it.
+func newSiteClient(flags *InputGetterFlags) (*siteClient, error) {
+	baseUrl, err := url.Parse(flags.BaseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing base url: %w", err)
+	}
+
+	// Create an HTTP client with a cookie jar
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cookie, err := readSessionCookie(flags.CookieFilePath, baseUrl.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	jar.SetCookies(baseUrl, []*http.Cookie{cookie})
+
+	userAgent := flags.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	return &siteClient{
+		httpClient: &http.Client{
+			Jar:     jar,
+			Timeout: 30 * time.Second,
+		},
+		userAgent:   userAgent,
+		retries:     flags.Retries,
+		minInterval: flags.MinInterval,
+		backoff:     time.Second,
+	}, nil
+}
+
+// get fetches rawUrl, retrying server errors with exponential backoff.
+func (c *siteClient) get(rawUrl string) (string, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		body, err := c.do(http.MethodGet, rawUrl, nil)
+		if err == nil || !errors.Is(err, ErrServer) || attempt >= c.retries {
+			return body, err
+		}
+
+		fmt.Printf("\t%v, retrying in %v\n", err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// postForm posts form to rawUrl. It is never retried, a submission must not
+// be sent twice.
+func (c *siteClient) postForm(rawUrl string, form url.Values) (string, error) {
+	return c.do(http.MethodPost, rawUrl, form)
+}
+
+// do sends a single request and returns the body of a 200 response. Any
+// other status is an *HTTPStatusError.
+func (c *siteClient) do(method string, rawUrl string, form url.Values) (string, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, rawUrl, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	requestLimiter.wait(c.minInterval)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		const maxBody = 200
+		message := strings.TrimSpace(string(content))
+		if len(message) > maxBody {
+			message = message[:maxBody] + "..."
+		}
+		return "", &HTTPStatusError{
+			Method:     method,
+			Url:        rawUrl,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       message,
+		}
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSiteClient(t *testing.T) {
+	failures := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("User-Agent = %q, want %q", got, "test-agent")
+		}
+
+		switch r.URL.Path {
+		case "/2024/day/1/input":
+			if failures > 0 {
+				failures--
+				http.Error(w, "try again", http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("3   4\n"))
+		case "/2024/day/2/input":
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		case "/2024/day/3/input":
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+		default:
+			http.Error(w, "down", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(retries int) *siteClient {
+		client, err := newSiteClient(&InputGetterFlags{
+			CookieFilePath: writeTestCookies(t),
+			BaseUrl:        server.URL,
+			UserAgent:      "test-agent",
+			Retries:        retries,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.backoff = time.Millisecond
+		return client
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		retries int
+		want    string
+		wantErr error
+	}{
+		{name: "retried-until-ok", path: "/2024/day/1/input", retries: 3, want: "3   4\n"},
+		{name: "not-logged-in", path: "/2024/day/2/input", retries: 3, wantErr: ErrNotLoggedIn},
+		{name: "not-unlocked", path: "/2024/day/3/input", retries: 3, wantErr: ErrNotFound},
+		{name: "retries-exhausted", path: "/2024/day/4/input", retries: 1, wantErr: ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newClient(tt.retries).get(server.URL + tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("get() error = %v, want %v", err, tt.wantErr)
+			}
+			var statusErr *HTTPStatusError
+			if tt.wantErr != nil && !errors.As(err, &statusErr) {
+				t.Errorf("get() error = %T, want *HTTPStatusError", err)
+			}
+			if got != tt.want {
+				t.Errorf("get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	rl := &rateLimiter{}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		rl.wait(20 * time.Millisecond)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least %v", elapsed, 40*time.Millisecond)
+	}
+}
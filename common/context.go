@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContextSolver is implemented by solvers that stop once their context is
+// done, which long searches should. When a solver implements it, the runner
+// and RunTests call these methods instead of the ones of ProblemSolver. The
+// result of a solve whose context is done is ignored.
+type ContextSolver interface {
+	SolvePart1Context(ctx context.Context, input string) Answer
+	SolvePart2Context(ctx context.Context, input string) Answer
+}
+
+// SolveContext solves part of ps on input and gives up when ctx is done,
+// returning its error. A panic of the solver is returned as a *PanicError.
+// Solvers implementing neither FallibleSolver nor ContextSolver cannot be
+// stopped: they keep running in the background after SolveContext returns.
+func SolveContext(ctx context.Context, ps ProblemSolver, part int, input string) (Answer, error) {
+	if err := ctx.Err(); err != nil {
+		return Answer{}, err
+	}
+
+	fn := solveFunc(ps, part)
+
+	type outcome struct {
+		answer Answer
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		var o outcome
+		defer func() {
+			if r := recover(); r != nil {
+				o.err = newPanicError(r)
+			}
+			done <- o
+		}()
+		o.answer, o.err = fn(ctx, input)
+	}()
+
+	select {
+	case o := <-done:
+		if o.err != nil {
+			return Answer{}, o.err
+		}
+		if err := ctx.Err(); err != nil {
+			return Answer{}, err
+		}
+		return o.answer, nil
+	case <-ctx.Done():
+		return Answer{}, ctx.Err()
+	}
+}
+
+// solveFunc returns the function solving part of ps, the richest variant
+// the solver implements.
+func solveFunc(ps ProblemSolver, part int) func(ctx context.Context, input string) (Answer, error) {
+	if fs, ok := ps.(FallibleSolver); ok {
+		if part == 2 {
+			return fs.TrySolvePart2
+		}
+		return fs.TrySolvePart1
+	}
+
+	if cs, ok := ps.(ContextSolver); ok {
+		fn := cs.SolvePart1Context
+		if part == 2 {
+			fn = cs.SolvePart2Context
+		}
+		return func(ctx context.Context, input string) (Answer, error) {
+			return fn(ctx, input), nil
+		}
+	}
+
+	fn := ps.SolvePart1
+	if part == 2 {
+		fn = ps.SolvePart2
+	}
+	return func(ctx context.Context, input string) (Answer, error) {
+		return fn(input), nil
+	}
+}
+
+// cancellationHint explains what happens to a solver that did not finish.
+func cancellationHint(ps ProblemSolver) string {
+	switch ps.(type) {
+	case ContextSolver, FallibleSolver:
+		return ""
+	}
+	return fmt.Sprintf(", %T does not implement ContextSolver and keeps running", ps)
+}
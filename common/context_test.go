@@ -0,0 +1,71 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stuckSolver struct {
+	stop chan struct{}
+}
+
+func (s *stuckSolver) SolvePart1(input string) Answer {
+	<-s.stop
+	return StringAnswer(input)
+}
+
+func (s *stuckSolver) SolvePart2(input string) Answer {
+	panic("boom")
+}
+
+type cancellableSolver struct{}
+
+func (s *cancellableSolver) SolvePart1(input string) Answer { return StringAnswer(input) }
+func (s *cancellableSolver) SolvePart2(input string) Answer { return StringAnswer(input) }
+
+func (s *cancellableSolver) SolvePart1Context(ctx context.Context, input string) Answer {
+	return StringAnswer("context " + input)
+}
+
+func (s *cancellableSolver) SolvePart2Context(ctx context.Context, input string) Answer {
+	<-ctx.Done()
+	return StringAnswer("too late")
+}
+
+func TestSolveContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	stuck := &stuckSolver{stop: make(chan struct{})}
+	defer close(stuck.stop)
+	if _, err := SolveContext(ctx, stuck, 1, "input"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("stuck solver: err = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	got, err := SolveContext(context.Background(), &cancellableSolver{}, 1, "input")
+	if err != nil || got.String() != "context input" {
+		t.Errorf("context solver = %q, %v, want %q", got, err, "context input")
+	}
+
+	if got, err := SolveContext(ctx, &cancellableSolver{}, 2, "input"); err == nil {
+		t.Errorf("cancelled solver = %q, want an error", got)
+	}
+}
+
+func TestSolveContextPanic(t *testing.T) {
+	_, err := SolveContext(context.Background(), &stuckSolver{}, 2, "input")
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("err = %v, want a *PanicError", err)
+	}
+	if panicErr.Value != "boom" {
+		t.Errorf("panic value = %v, want %q", panicErr.Value, "boom")
+	}
+	if !strings.Contains(panicErr.Location, "context_test.go") {
+		t.Errorf("location = %q, want the solver", panicErr.Location)
+	}
+}
@@ -0,0 +1,98 @@
+package common
+
+import "fmt"
+
+// Direction is one of the four directions on a grid, clockwise from Up.
+// Up is towards row 0.
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+// Directions are the four directions, clockwise from Up.
+var Directions = []Direction{Up, Right, Down, Left}
+
+// ParseDirection parses an arrow (^>v<), a letter of UDLR or a compass
+// point of NESW.
+func ParseDirection(r rune) (Direction, error) {
+	switch r {
+	case '^', 'U', 'N':
+		return Up, nil
+	case '>', 'R', 'E':
+		return Right, nil
+	case 'v', 'D', 'S':
+		return Down, nil
+	case '<', 'L', 'W':
+		return Left, nil
+	}
+	return 0, fmt.Errorf("invalid direction %q, want one of ^>v<, UDLR or NESW", r)
+}
+
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+// Delta is the offset of a step in the direction.
+func (d Direction) Delta() Point {
+	return neighborOffsets4[d]
+}
+
+// Arrow renders the direction as one of ^>v<.
+func (d Direction) Arrow() rune {
+	return []rune("^>v<")[d]
+}
+
+func (d Direction) String() string {
+	switch d {
+	case Up:
+		return "up"
+	case Right:
+		return "right"
+	case Down:
+		return "down"
+	case Left:
+		return "left"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// Pose is where a walker on a grid stands and where it faces. Poses are
+// comparable, a map of them remembers visited states.
+type Pose struct {
+	Point     Point
+	Direction Direction
+}
+
+// Ahead returns the point the pose faces.
+func (p Pose) Ahead() Point {
+	return p.Point.Add(p.Direction.Delta())
+}
+
+// Step returns the pose one step ahead, facing the same way.
+func (p Pose) Step() Pose {
+	return Pose{p.Ahead(), p.Direction}
+}
+
+func (p Pose) TurnRight() Pose {
+	return Pose{p.Point, p.Direction.TurnRight()}
+}
+
+func (p Pose) TurnLeft() Pose {
+	return Pose{p.Point, p.Direction.TurnLeft()}
+}
+
+func (p Pose) Reverse() Pose {
+	return Pose{p.Point, p.Direction.Reverse()}
+}
@@ -0,0 +1,56 @@
+package common
+
+import "testing"
+
+func TestParseDirection(t *testing.T) {
+	for _, group := range []string{"^>v<", "URDL", "NESW"} {
+		for i, r := range group {
+			d, err := ParseDirection(r)
+			if err != nil || d != Directions[i] {
+				t.Errorf("ParseDirection(%q) = %v, %v, want %v", r, d, err, Directions[i])
+			}
+		}
+	}
+	if _, err := ParseDirection('x'); err == nil {
+		t.Error("ParseDirection('x') succeeded")
+	}
+}
+
+func TestDirectionTurns(t *testing.T) {
+	for _, d := range Directions {
+		if got := d.TurnRight().TurnLeft(); got != d {
+			t.Errorf("%v.TurnRight().TurnLeft() = %v", d, got)
+		}
+		if got := d.TurnRight().TurnRight(); got != d.Reverse() {
+			t.Errorf("%v turned right twice = %v, want %v", d, got, d.Reverse())
+		}
+		if got, want := d.Reverse().Delta(), (Point{-d.Delta().Row, -d.Delta().Col}); got != want {
+			t.Errorf("%v.Reverse().Delta() = %v, want %v", d, got, want)
+		}
+	}
+	if got := Left.TurnRight(); got != Up {
+		t.Errorf("Left.TurnRight() = %v, want up", got)
+	}
+	if got := string(Down.Arrow()); got != "v" {
+		t.Errorf("Down.Arrow() = %q, want \"v\"", got)
+	}
+}
+
+func TestPose(t *testing.T) {
+	start := Pose{Point{2, 2}, Up}
+	got := start.Step().TurnRight().Step().Step()
+	if want := (Pose{Point{1, 4}, Right}); got != want {
+		t.Errorf("walked to %v, want %v", got, want)
+	}
+
+	// Walking a square ends where it started.
+	visited := map[Pose]bool{}
+	pose := start
+	for !visited[pose] {
+		visited[pose] = true
+		pose = pose.Step().TurnRight()
+	}
+	if pose != start || len(visited) != 4 {
+		t.Errorf("walked a square back to %v in %d steps, want %v in 4", pose, len(visited), start)
+	}
+}
@@ -1,10 +1,104 @@
 package common
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
 
+// CheckErr panics with e, prefixed by msg, when it is not nil. The runner
+// and RunTests turn the panic into an error pointing at the caller.
 func CheckErr(e error, msg string) {
 	if e != nil {
-		fmt.Println(msg)
-		panic(e)
+		panic(fmt.Errorf("%s: %w", msg, e))
 	}
 }
+
+// FallibleSolver is implemented by solvers that return an error for input
+// they cannot solve, like an *InputError from the parse helpers, instead of
+// panicking. The runner and RunTests prefer these methods over the ones of
+// ContextSolver and ProblemSolver.
+type FallibleSolver interface {
+	TrySolvePart1(ctx context.Context, input string) (Answer, error)
+	TrySolvePart2(ctx context.Context, input string) (Answer, error)
+}
+
+// PanicError is returned in place of a panic during a solve.
+type PanicError struct {
+	Value any
+	// Location is the file and line of the solver code closest to the
+	// panic, empty when there is none.
+	Location string
+}
+
+func (e *PanicError) Error() string {
+	if e.Location == "" {
+		return fmt.Sprintf("panic: %v", e.Value)
+	}
+	return fmt.Sprintf("panic at %s: %v", e.Location, e.Value)
+}
+
+// Unwrap returns the panic value when it is an error, as it is for CheckErr.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// newPanicError captures the panic value r along with its location. It must
+// be called from the deferred function that recovered r.
+func newPanicError(r any) *PanicError {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if isSolverFrame(frame) {
+			return &PanicError{Value: r, Location: fmt.Sprintf("%s:%d", shortPath(frame.File), frame.Line)}
+		}
+		if !more {
+			return &PanicError{Value: r}
+		}
+	}
+}
+
+// commonPackage is the import path of this package, whose frames are not
+// the cause of a panic.
+var commonPackage = reflect.TypeOf(PanicError{}).PkgPath()
+
+// isSolverFrame reports whether frame is outside the standard library and
+// this package, which is where solvers make the mistakes panics are about.
+func isSolverFrame(frame runtime.Frame) bool {
+	name := frame.Function
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return false
+	}
+	pkg := name[:slash+1+dot]
+
+	// Only import paths outside the standard library start with a domain.
+	domain, _, _ := strings.Cut(pkg, "/")
+	switch {
+	case !strings.Contains(domain, "."):
+		return false
+	case pkg == commonPackage:
+		// Tests of this package are solvers too.
+		return strings.HasSuffix(frame.File, "_test.go")
+	default:
+		return true
+	}
+}
+
+// shortPath keeps the last three elements of path, enough for
+// YYYY/dayNN/main.go to tell the day.
+func shortPath(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) > 3 {
+		parts = parts[len(parts)-3:]
+	}
+	return strings.Join(parts, "/")
+}
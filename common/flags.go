@@ -1,32 +1,171 @@
 package common
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 )
 
 type ProblemSolverFlags struct {
-	Part int
+	Parts     []int
+	InputPath string
+	Example   bool
+	// Params overrides parameters of solvers implementing
+	// ParameterizedSolver.
+	Params  map[string]string
+	Timeout time.Duration
+	Verbose bool
+	Debug   bool
+	// LogDir receives a log file per day instead of stderr when set.
+	LogDir     string
+	LedgerPath string
+	// HistoryPath receives the timing of every solve of the real input.
+	HistoryPath string
+	Json        bool
+	// CPUProfileDir, MemProfileDir and TraceDir receive a file per part,
+	// named like DIR/YYYY/dayNN-partN.cpu.pprof, when set.
+	CPUProfileDir string
+	MemProfileDir string
+	TraceDir      string
+	// PprofAddr serves net/http/pprof while the runner runs, when set.
+	PprofAddr string
+}
+
+// partsValue parses -part: 1, 2 or both.
+type partsValue struct {
+	parts *[]int
+}
+
+func (pv partsValue) String() string {
+	if pv.parts == nil || len(*pv.parts) != 1 {
+		return "both"
+	}
+	return Itoa((*pv.parts)[0])
+}
+
+func (pv partsValue) Set(value string) error {
+	switch value {
+	case "1":
+		*pv.parts = []int{1}
+	case "2":
+		*pv.parts = []int{2}
+	case "both":
+		*pv.parts = []int{1, 2}
+	default:
+		return fmt.Errorf("invalid part %q, want 1, 2 or both", value)
+	}
+	return nil
+}
+
+// paramsValue parses repeated -set name=value flags.
+type paramsValue struct {
+	params *map[string]string
+}
+
+func (pv paramsValue) String() string {
+	if pv.params == nil {
+		return ""
+	}
+	return formatParams(*pv.params)
+}
+
+func (pv paramsValue) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("invalid parameter %q, want name=value", value)
+	}
+	if *pv.params == nil {
+		*pv.params = make(map[string]string)
+	}
+	(*pv.params)[name] = strings.TrimSpace(val)
+	return nil
+}
+
+// BindFlags registers the solver flags on fs, so commands that take extra
+// flags of their own can share the same definitions.
+func (f *ProblemSolverFlags) BindFlags(fs *flag.FlagSet) {
+	f.Parts = []int{1}
+	fs.Var(partsValue{&f.Parts}, "part", "part 1, 2 or both")
+	fs.StringVar(&f.InputPath, "input", "", "read the input from this file instead of the embedded one, - for stdin")
+	fs.BoolVar(&f.Example, "example", false, "use the example input of the day instead of the embedded one")
+	fs.Var(paramsValue{&f.Params}, "set", "set a solver parameter as name=value, can be repeated")
+	fs.DurationVar(&f.Timeout, "timeout", 0, "give up on a part after this long, 0 for no limit")
+	fs.BoolVar(&f.Verbose, "v", false, "log the progress of solvers")
+	fs.BoolVar(&f.Debug, "debug", false, "log everything solvers log, implies -v")
+	fs.StringVar(&f.LogDir, "logdir", "", "write the log of each day to DIR/YYYY/dayNN.log instead of stderr")
+	fs.StringVar(&f.LedgerPath, "ledger", InModuleRoot(DefaultLedgerPath), "path to the answer ledger, empty to disable")
+	fs.StringVar(&f.HistoryPath, "history", InModuleRoot(DefaultHistoryPath), "append the timing of solves of the real input to this file, empty to disable; profiled, traced and verbose runs are left out")
+	fs.BoolVar(&f.Json, "json", false, "print the result and its timing as a JSON line")
+	fs.StringVar(&f.CPUProfileDir, "cpuprofile", "", "write a CPU profile of each part to DIR/YYYY/dayNN-partN.cpu.pprof")
+	fs.StringVar(&f.MemProfileDir, "memprofile", "", "write a memory profile of each part to DIR/YYYY/dayNN-partN.mem.pprof")
+	fs.StringVar(&f.TraceDir, "trace", "", "write an execution trace of each part to DIR/YYYY/dayNN-partN.trace")
+	fs.StringVar(&f.PprofAddr, "pprof", "", "serve net/http/pprof on this address while solving, like :6060 for localhost")
 }
 
 func ParseSolverFlags(args []string, debug bool) (*ProblemSolverFlags, error) {
 	parsedFlags := ProblemSolverFlags{}
 	fs := flag.NewFlagSet("aoc", flag.ContinueOnError)
 
-	fs.IntVar(&parsedFlags.Part, "part", 1, "part 1 or 2")
+	parsedFlags.BindFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
 	}
+	if err := parsedFlags.Validate(); err != nil {
+		return nil, err
+	}
 
 	if debug {
 		fmt.Println("Parsed flags:")
-		fmt.Println("\tPart:", parsedFlags.Part)
+		fmt.Println("\tParts:", parsedFlags.Parts)
+		fmt.Println("\tInputPath:", parsedFlags.InputPath)
+		fmt.Println("\tExample:", parsedFlags.Example)
+		fmt.Println("\tParams:", formatParams(parsedFlags.Params))
+		fmt.Println("\tTimeout:", parsedFlags.Timeout)
+		fmt.Println("\tLogLevel:", parsedFlags.LogLevel())
+		fmt.Println("\tLogDir:", parsedFlags.LogDir)
+		fmt.Println("\tLedgerPath:", parsedFlags.LedgerPath)
+		fmt.Println("\tHistoryPath:", parsedFlags.HistoryPath)
+		fmt.Println("\tJson:", parsedFlags.Json)
+		fmt.Println("\tCPUProfileDir:", parsedFlags.CPUProfileDir)
+		fmt.Println("\tMemProfileDir:", parsedFlags.MemProfileDir)
+		fmt.Println("\tTraceDir:", parsedFlags.TraceDir)
+		fmt.Println("\tPprofAddr:", parsedFlags.PprofAddr)
 	}
 
 	return &parsedFlags, nil
 }
 
+// LogLevel returns the level solvers log at.
+func (f *ProblemSolverFlags) LogLevel() LogLevel {
+	switch {
+	case f.Debug:
+		return LevelDebug
+	case f.Verbose:
+		return LevelInfo
+	default:
+		return LevelWarn
+	}
+}
+
+// Instrumented reports whether profiling, tracing or verbose logging slow
+// the solve down, its timing is not representative then.
+func (f *ProblemSolverFlags) Instrumented() bool {
+	return f.CPUProfileDir != "" || f.MemProfileDir != "" || f.TraceDir != "" || f.PprofAddr != "" ||
+		f.LogLevel() != LevelWarn
+}
+
+// Validate checks flags that cannot be combined.
+func (f *ProblemSolverFlags) Validate() error {
+	if f.InputPath != "" && f.Example {
+		return errors.New("-input and -example are mutually exclusive")
+	}
+	return nil
+}
+
 func MustParseSolverFlags(args []string, debug bool) *ProblemSolverFlags {
 	flags, err := ParseSolverFlags(args, debug)
 	CheckErr(err, "Failed to parse flags")
@@ -38,6 +177,24 @@ type InputGetterFlags struct {
 	Day            int
 	CookieFilePath string
 	BaseUrl        string
+	Description    bool
+	UserAgent      string
+	Retries        int
+	MinInterval    time.Duration
+	Wait           bool
+	CacheDir       string
+	Refresh        bool
+}
+
+// BindClientFlags registers the flags needed to talk to the website on fs,
+// for commands that take the year and day some other way.
+func (ig *InputGetterFlags) BindClientFlags(fs *flag.FlagSet) {
+	fs.StringVar(&ig.CookieFilePath, "cookie", "cookie.txt", "path to cookie file")
+	fs.StringVar(&ig.BaseUrl, "baseurl", AOCBaseURL, "base url")
+	fs.StringVar(&ig.UserAgent, "useragent", DefaultUserAgent, "User-Agent sent to the site, please add contact details")
+	fs.IntVar(&ig.Retries, "retries", 3, "retries on server errors")
+	fs.DurationVar(&ig.MinInterval, "min-interval", time.Second, "minimum time between two requests")
+	fs.BoolVar(&ig.Wait, "wait", false, "wait for the puzzle to unlock instead of failing")
 }
 
 func ParseInputGetterFlags(args []string, debug bool) (*InputGetterFlags, error) {
@@ -46,8 +203,10 @@ func ParseInputGetterFlags(args []string, debug bool) (*InputGetterFlags, error)
 
 	fs.IntVar(&parsedFlags.Year, "year", 2023, "year")
 	fs.IntVar(&parsedFlags.Day, "day", 1, "day")
-	fs.StringVar(&parsedFlags.CookieFilePath, "cookie", "cookie.txt", "path to cookie file")
-	fs.StringVar(&parsedFlags.BaseUrl, "baseurl", AOCBaseURL, "base url")
+	fs.BoolVar(&parsedFlags.Description, "description", true, "also save the puzzle description as README.md")
+	fs.StringVar(&parsedFlags.CacheDir, "cachedir", "", "directory holding the YYYY/dayNN inputs, the module root if empty")
+	fs.BoolVar(&parsedFlags.Refresh, "refresh", false, "fetch the input even if it is cached")
+	parsedFlags.BindClientFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
@@ -59,6 +218,13 @@ func ParseInputGetterFlags(args []string, debug bool) (*InputGetterFlags, error)
 		fmt.Println("\tDay:", parsedFlags.Day)
 		fmt.Println("\tCookieFilePath:", parsedFlags.CookieFilePath)
 		fmt.Println("\tBaseUrl:", parsedFlags.BaseUrl)
+		fmt.Println("\tDescription:", parsedFlags.Description)
+		fmt.Println("\tUserAgent:", parsedFlags.UserAgent)
+		fmt.Println("\tRetries:", parsedFlags.Retries)
+		fmt.Println("\tMinInterval:", parsedFlags.MinInterval)
+		fmt.Println("\tWait:", parsedFlags.Wait)
+		fmt.Println("\tCacheDir:", parsedFlags.CacheDir)
+		fmt.Println("\tRefresh:", parsedFlags.Refresh)
 	}
 
 	return &parsedFlags, nil
@@ -74,6 +240,30 @@ func (ig *InputGetterFlags) InputUrl() string {
 	return fmt.Sprintf("%s/%d/day/%d/input", ig.BaseUrl, ig.Year, ig.Day)
 }
 
+func (ig *InputGetterFlags) PuzzleUrl() string {
+	return fmt.Sprintf("%s/%d/day/%d", ig.BaseUrl, ig.Year, ig.Day)
+}
+
+func (ig *InputGetterFlags) AnswerUrl() string {
+	return fmt.Sprintf("%s/%d/day/%d/answer", ig.BaseUrl, ig.Year, ig.Day)
+}
+
 func (ig *InputGetterFlags) CacheKey() string {
 	return fmt.Sprintf("%d/day%02d/input.txt", ig.Year, ig.Day)
 }
+
+func (ig *InputGetterFlags) DescriptionKey() string {
+	return fmt.Sprintf("%d/day%02d/README.md", ig.Year, ig.Day)
+}
+
+// CacheRoot returns the directory CacheKey and DescriptionKey are relative to.
+func (ig *InputGetterFlags) CacheRoot() (string, error) {
+	if ig.CacheDir != "" {
+		return ig.CacheDir, nil
+	}
+	root, err := FindModuleRoot()
+	if err != nil {
+		return "", fmt.Errorf("%w, pass -cachedir", err)
+	}
+	return root, nil
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSolverFlagsParts(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []int
+	}{
+		{nil, []int{1}},
+		{[]string{"-part", "2"}, []int{2}},
+		{[]string{"-part", "both"}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		flags, err := ParseSolverFlags(tt.args, false)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if !reflect.DeepEqual(flags.Parts, tt.want) {
+			t.Errorf("%v: parts = %v, want %v", tt.args, flags.Parts, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"-part", "3"},
+		{"-input", "input.txt", "-example"},
+	} {
+		if _, err := ParseSolverFlags(args, false); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestCheckExampleInputNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"example_input.txt", "example_part1_input.txt", "example_part2_input.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := checkExampleInputNames(dir); err != nil {
+		t.Errorf("checkExampleInputNames() = %v, want nil", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "example_input2.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkExampleInputNames(dir); err == nil {
+		t.Error("checkExampleInputNames() accepted example_input2.txt")
+	}
+}
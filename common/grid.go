@@ -1,5 +1,188 @@
 package common
 
-type Point struct {
-	Row, Col int
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// neighborOffsets4 are the offsets of the orthogonal neighbors of a point,
+// clockwise from up, followed by the diagonal ones in neighborOffsets8.
+var (
+	neighborOffsets4 = []Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	neighborOffsets8 = []Point{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+)
+
+// Grid is a rectangular grid of cells, like the maps most puzzles are drawn
+// on. Row 0 is the first line of the input.
+type Grid[T comparable] struct {
+	Rows, Cols int
+	cells      []T
+}
+
+// NewGrid returns a grid of rows by cols zero cells.
+func NewGrid[T comparable](rows, cols int) *Grid[T] {
+	return &Grid[T]{Rows: rows, Cols: cols, cells: make([]T, rows*cols)}
+}
+
+// ParseGrid reads a grid of runes, a line per row. Every line must be as
+// long as the first.
+func ParseGrid(input string) (*Grid[rune], error) {
+	return ParseGridFunc(input, func(r rune) (rune, error) { return r, nil })
+}
+
+// MustParseGrid is ParseGrid for input known to be well formed.
+func MustParseGrid(input string) *Grid[rune] {
+	grid, err := ParseGrid(input)
+	CheckErr(err, "Failed to parse grid")
+	return grid
+}
+
+// ParseGridFunc reads a grid whose cells are parsed from a rune each by
+// parse. Errors point at the rune parse failed on.
+func ParseGridFunc[T comparable](input string, parse func(r rune) (T, error)) (*Grid[T], error) {
+	lines := InputLines(input)
+	if len(lines) == 0 {
+		return nil, errors.New("empty grid")
+	}
+
+	grid := NewGrid[T](len(lines), utf8.RuneCountInString(lines[0].Text))
+	for r, line := range lines {
+		if n := utf8.RuneCountInString(line.Text); n != grid.Cols {
+			return nil, line.Errorf("want %d cells like the first line, got %d", grid.Cols, n)
+		}
+		c := 0
+		for i, char := range line.Text {
+			v, err := parse(char)
+			if err != nil {
+				return nil, line.slice(i, i+utf8.RuneLen(char)).Errorf("%v", err)
+			}
+			grid.cells[r*grid.Cols+c] = v
+			c++
+		}
+	}
+	return grid, nil
+}
+
+// InBounds reports whether p is a cell of the grid.
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < g.Rows && p.Col >= 0 && p.Col < g.Cols
+}
+
+// At returns the cell at p, the zero value outside of the grid.
+func (g *Grid[T]) At(p Point) T {
+	if !g.InBounds(p) {
+		var zero T
+		return zero
+	}
+	return g.cells[p.Row*g.Cols+p.Col]
+}
+
+// Set changes the cell at p, which must be in the grid.
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v outside of %dx%d grid", p, g.Rows, g.Cols))
+	}
+	g.cells[p.Row*g.Cols+p.Col] = v
+}
+
+// Neighbors4 returns the orthogonal neighbors of p in the grid, clockwise
+// from the one above.
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, neighborOffsets4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p in the
+// grid, clockwise from the one above.
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, neighborOffsets8)
+}
+
+func (g *Grid[T]) neighbors(p Point, offsets []Point) []Point {
+	neighbors := make([]Point, 0, len(offsets))
+	for _, o := range offsets {
+		if n := p.Add(o); g.InBounds(n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+// Find returns the first cell holding v, row by row.
+func (g *Grid[T]) Find(v T) (Point, bool) {
+	for i, cell := range g.cells {
+		if cell == v {
+			return g.point(i), true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every cell holding v, row by row.
+func (g *Grid[T]) FindAll(v T) []Point {
+	var points []Point
+	for i, cell := range g.cells {
+		if cell == v {
+			points = append(points, g.point(i))
+		}
+	}
+	return points
+}
+
+// Points returns every cell of the grid, row by row.
+func (g *Grid[T]) Points() []Point {
+	points := make([]Point, len(g.cells))
+	for i := range g.cells {
+		points[i] = g.point(i)
+	}
+	return points
+}
+
+func (g *Grid[T]) point(i int) Point {
+	return Point{i / g.Cols, i % g.Cols}
+}
+
+// Row returns the cells of row r. It shares the cells of the grid, setting
+// one sets it in the grid.
+func (g *Grid[T]) Row(r int) []T {
+	return g.cells[r*g.Cols : (r+1)*g.Cols : (r+1)*g.Cols]
+}
+
+// Col returns a copy of the cells of column c, top to bottom.
+func (g *Grid[T]) Col(c int) []T {
+	col := make([]T, g.Rows)
+	for r := range col {
+		col[r] = g.cells[r*g.Cols+c]
+	}
+	return col
+}
+
+// Clone returns a grid with a copy of the cells of g.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Rows: g.Rows, Cols: g.Cols, cells: append([]T(nil), g.cells...)}
+}
+
+// String renders the grid a line per row, the way it was parsed for grids
+// of runes.
+func (g *Grid[T]) String() string {
+	var sb strings.Builder
+	for r := 0; r < g.Rows; r++ {
+		for _, cell := range g.Row(r) {
+			writeCell(&sb, cell)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func writeCell(sb *strings.Builder, cell any) {
+	switch v := cell.(type) {
+	case rune:
+		sb.WriteRune(v)
+	case byte:
+		sb.WriteByte(v)
+	default:
+		fmt.Fprint(sb, v)
+	}
 }
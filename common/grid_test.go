@@ -0,0 +1,75 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseGrid(t *testing.T) {
+	grid, err := ParseGrid("#.S\n.#.\nE..\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid.Rows != 3 || grid.Cols != 3 {
+		t.Fatalf("grid is %dx%d, want 3x3", grid.Rows, grid.Cols)
+	}
+	if got := grid.At(Point{0, 2}); got != 'S' {
+		t.Errorf("At(0, 2) = %q, want 'S'", got)
+	}
+	if got := grid.At(Point{-1, 0}); got != 0 {
+		t.Errorf("At(-1, 0) = %q, want the zero value", got)
+	}
+	if p, ok := grid.Find('E'); !ok || p != (Point{2, 0}) {
+		t.Errorf("Find('E') = %v, %v, want {2 0}", p, ok)
+	}
+	if got, want := grid.FindAll('#'), []Point{{0, 0}, {1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll('#') = %v, want %v", got, want)
+	}
+	if got := string(grid.Col(0)); got != "#.E" {
+		t.Errorf("Col(0) = %q, want \"#.E\"", got)
+	}
+
+	grid.Row(1)[0] = 'x'
+	grid.Clone().Set(Point{2, 2}, 'y')
+	if got, want := grid.String(), "#.S\nx#.\nE..\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestParseGridErrors(t *testing.T) {
+	var inputErr *InputError
+
+	_, err := ParseGrid("...\n..\n")
+	if !errors.As(err, &inputErr) || inputErr.Line != 2 || inputErr.Column != 0 {
+		t.Errorf("ParseGrid(ragged) = %v, want an error on line 2", err)
+	}
+
+	_, err = ParseGridFunc("12\n3x", func(r rune) (int, error) {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("want a digit, got %q", r)
+		}
+		return int(r - '0'), nil
+	})
+	if !errors.As(err, &inputErr) || inputErr.Line != 2 || inputErr.Column != 2 {
+		t.Errorf("ParseGridFunc(x) = %v, want an error on line 2, column 2", err)
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	grid := NewGrid[int](3, 3)
+
+	if got, want := grid.Neighbors4(Point{1, 1}), []Point{{0, 1}, {1, 2}, {2, 1}, {1, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Neighbors4(center) = %v, want %v", got, want)
+	}
+	if got, want := grid.Neighbors4(Point{0, 0}), []Point{{0, 1}, {1, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Neighbors4(corner) = %v, want %v", got, want)
+	}
+	if got := len(grid.Neighbors8(Point{1, 1})); got != 8 {
+		t.Errorf("Neighbors8(center) has %d points, want 8", got)
+	}
+	if got, want := grid.Neighbors8(Point{2, 2}), []Point{{1, 2}, {2, 1}, {1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Neighbors8(corner) = %v, want %v", got, want)
+	}
+}
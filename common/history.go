@@ -0,0 +1,235 @@
+package common
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultHistoryPath is the history in the module root, see InModuleRoot.
+const DefaultHistoryPath = "perf_history.jsonl"
+
+// Sources of history entries, timings of single runs and benchmark averages
+// are not compared with each other.
+const (
+	HistorySourceRun   = "run"
+	HistorySourceBench = "bench"
+)
+
+// HistoryEntry is the timing of a part at some commit.
+type HistoryEntry struct {
+	Commit   string        `json:"commit"`
+	Time     time.Time     `json:"time"`
+	Source   string        `json:"source"`
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Duration time.Duration `json:"duration_ns"`
+	Allocs   uint64        `json:"allocs"`
+}
+
+// AppendHistory adds entries to the history file at path, a JSON object per
+// line. Existing lines are never rewritten.
+func AppendHistory(path string, entries ...HistoryEntry) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadHistory reads the history file at path, oldest entry first. A missing
+// file is an empty history.
+func LoadHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parsing history %s, line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// CurrentCommit returns the short hash of the checked out commit, with a
+// +dirty suffix when tracked files are modified, or "unknown" outside of a
+// git checkout.
+func CurrentCommit() string {
+	root, err := FindModuleRoot()
+	if err != nil {
+		return "unknown"
+	}
+
+	git := func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	commit, err := git("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "unknown"
+	}
+	if status, err := git("status", "--porcelain", "--untracked-files=no"); err == nil && status != "" {
+		commit += "+dirty"
+	}
+	return commit
+}
+
+// HistorySeries are the entries of one part from one source, oldest first.
+type HistorySeries struct {
+	Year, Day, Part int
+	Source          string
+	Entries         []HistoryEntry
+}
+
+// GroupHistory splits entries into a series per part and source, sorted by
+// year, day, part and source.
+func GroupHistory(entries []HistoryEntry) []*HistorySeries {
+	type key struct {
+		year, day, part int
+		source          string
+	}
+	byKey := make(map[key]*HistorySeries)
+	var series []*HistorySeries
+	for _, entry := range entries {
+		k := key{entry.Year, entry.Day, entry.Part, entry.Source}
+		s, ok := byKey[k]
+		if !ok {
+			s = &HistorySeries{Year: entry.Year, Day: entry.Day, Part: entry.Part, Source: entry.Source}
+			byKey[k] = s
+			series = append(series, s)
+		}
+		s.Entries = append(s.Entries, entry)
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		a, b := series[i], series[j]
+		switch {
+		case a.Year != b.Year:
+			return a.Year < b.Year
+		case a.Day != b.Day:
+			return a.Day < b.Day
+		case a.Part != b.Part:
+			return a.Part < b.Part
+		default:
+			return a.Source < b.Source
+		}
+	})
+	return series
+}
+
+// Regression is an entry that took more time or allocations than the median
+// of the entries before it.
+type Regression struct {
+	Entry HistoryEntry
+	// Metric is "time" or "allocs".
+	Metric string
+	Value  float64
+	Median float64
+}
+
+// Change is how much worse the entry is than the median, 0.5 for 50%.
+func (r Regression) Change() float64 {
+	return r.Value/r.Median - 1
+}
+
+// MedianBefore returns the median duration of the window entries before
+// entry i, false when there are none.
+func (s *HistorySeries) MedianBefore(i, window int) (time.Duration, bool) {
+	values := s.window(i, window, func(e HistoryEntry) float64 { return float64(e.Duration) })
+	if len(values) == 0 {
+		return 0, false
+	}
+	return time.Duration(median(values)), true
+}
+
+// minRegressionHistory is how many entries a median needs before it is
+// trusted, a single earlier run is too noisy.
+const minRegressionHistory = 3
+
+// Regressions returns the entries whose time or allocations exceed the
+// median of the window entries before them by more than threshold, 0.2 for
+// 20%. Entries with fewer than minRegressionHistory entries before them, or
+// window when smaller, are never flagged.
+func (s *HistorySeries) Regressions(window int, threshold float64) []Regression {
+	metrics := []struct {
+		name  string
+		value func(HistoryEntry) float64
+	}{
+		{"time", func(e HistoryEntry) float64 { return float64(e.Duration) }},
+		{"allocs", func(e HistoryEntry) float64 { return float64(e.Allocs) }},
+	}
+
+	var regressions []Regression
+	for i, entry := range s.Entries {
+		for _, metric := range metrics {
+			previous := s.window(i, window, metric.value)
+			if len(previous) == 0 || len(previous) < minRegressionHistory && len(previous) < window {
+				continue
+			}
+			med := median(previous)
+			if value := metric.value(entry); med > 0 && value > med*(1+threshold) {
+				regressions = append(regressions, Regression{Entry: entry, Metric: metric.name, Value: value, Median: med})
+			}
+		}
+	}
+	return regressions
+}
+
+// window returns the values of up to n entries before entry i.
+func (s *HistorySeries) window(i, n int, value func(HistoryEntry) float64) []float64 {
+	start := i - n
+	if start < 0 {
+		start = 0
+	}
+	var values []float64
+	for _, entry := range s.Entries[start:i] {
+		values = append(values, value(entry))
+	}
+	return values
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
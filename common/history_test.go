@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	entries, err := LoadHistory(path)
+	if err != nil || entries != nil {
+		t.Fatalf("LoadHistory(missing) = %v, %v, want nothing", entries, err)
+	}
+
+	at := time.Date(2024, 12, 16, 6, 0, 0, 0, time.UTC)
+	want := []HistoryEntry{
+		{Commit: "abc1234", Time: at, Source: HistorySourceRun, Year: 2024, Day: 16, Part: 1, Duration: time.Second, Allocs: 10},
+		{Commit: "abc1234", Time: at, Source: HistorySourceRun, Year: 2024, Day: 16, Part: 2, Duration: time.Minute, Allocs: 20},
+	}
+	// Appending twice keeps the first entry.
+	if err := AppendHistory(path, want[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendHistory(path, want[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadHistory() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHistoryRegressions(t *testing.T) {
+	var entries []HistoryEntry
+	add := func(day int, ms int, allocs uint64) {
+		entries = append(entries, HistoryEntry{Source: HistorySourceRun, Year: 2024, Day: day, Part: 1, Duration: time.Duration(ms) * time.Millisecond, Allocs: allocs})
+	}
+	add(2, 10, 5)
+	add(1, 100, 5)
+	add(1, 110, 5)
+	add(1, 90, 5)
+	add(1, 150, 5) // 50% over the median of 100ms.
+	add(1, 105, 5) // Within 20% of the median of 105ms.
+	add(1, 100, 8) // 60% more allocations.
+
+	series := GroupHistory(entries)
+	if len(series) != 2 || series[0].Day != 1 || series[1].Day != 2 {
+		t.Fatalf("GroupHistory() = %+v, want day 1 then day 2", series)
+	}
+	if len(series[0].Entries) != 6 {
+		t.Fatalf("day 1 has %d entries, want 6", len(series[0].Entries))
+	}
+
+	if m, ok := series[0].MedianBefore(4, 3); !ok || m != 110*time.Millisecond {
+		t.Errorf("MedianBefore(4, 3) = %v, %v, want 110ms", m, ok)
+	}
+	if _, ok := series[0].MedianBefore(0, 3); ok {
+		t.Error("MedianBefore(0, 3) found a median without previous entries")
+	}
+
+	regressions := series[0].Regressions(3, 0.2)
+	if len(regressions) != 2 {
+		t.Fatalf("Regressions() = %+v, want 2", regressions)
+	}
+	if r := regressions[0]; r.Metric != "time" || r.Entry.Duration != 150*time.Millisecond || r.Change() != 0.5 {
+		t.Errorf("first regression = %+v, want time of 150ms, +50%%", r)
+	}
+	if r := regressions[1]; r.Metric != "allocs" || r.Value != 8 || r.Median != 5 {
+		t.Errorf("second regression = %+v, want 8 allocs over 5", r)
+	}
+	if regressions := series[1].Regressions(3, 0.2); len(regressions) != 0 {
+		t.Errorf("single entry regressed: %+v", regressions)
+	}
+}
+
+func TestRunnerHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	solution := &Solution{Year: 2024, Day: 3, Solver: &plainSolver{}, Input: "input"}
+
+	for _, flags := range []*ProblemSolverFlags{
+		{Parts: []int{1}, HistoryPath: path},
+		// Logging and profiling inflate the timings, they are left out.
+		{Parts: []int{2}, HistoryPath: path, Verbose: true},
+		{Parts: []int{2}, HistoryPath: path, TraceDir: t.TempDir()},
+	} {
+		if _, err := NewProblemRunner(flags, solution).Run(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Part != 1 {
+		t.Errorf("LoadHistory() = %+v, want only the plain run of part 1", entries)
+	}
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// InputError points at the place in the input a solver could not parse.
+// Line and Column start at 1, Column is 0 when the whole line is wrong.
+type InputError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *InputError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("input line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("input line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// InputField is a piece of a line of input that remembers where it was
+// found, so it can report where parsing it failed.
+type InputField struct {
+	Text   string
+	Line   int
+	Column int
+}
+
+// InputLine is a line of input with its number.
+type InputLine struct {
+	InputField
+}
+
+// InputLines splits input into numbered lines, dropping surrounding blank
+// lines like ReadAsLines.
+func InputLines(input string) []InputLine {
+	// Count the blank lines TrimSpace drops so numbers match the file.
+	first := 1 + strings.Count(input[:len(input)-len(strings.TrimLeftFunc(input, unicode.IsSpace))], "\n")
+	var lines []InputLine
+	for i, text := range ReadAsLines(input) {
+		lines = append(lines, InputLine{InputField{Text: text, Line: first + i, Column: 1}})
+	}
+	return lines
+}
+
+// Errorf returns an error pointing at the field.
+func (f InputField) Errorf(format string, args ...any) error {
+	return &InputError{Line: f.Line, Column: f.Column, Err: fmt.Errorf(format, args...)}
+}
+
+// Errorf returns an error pointing at the whole line.
+func (l InputLine) Errorf(format string, args ...any) error {
+	return &InputError{Line: l.Line, Err: fmt.Errorf(format, args...)}
+}
+
+// Fields splits the field around runs of whitespace.
+func (f InputField) Fields() []InputField {
+	var fields []InputField
+	start := -1
+	for i, r := range f.Text + " " {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			fields = append(fields, f.slice(start, i))
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	return fields
+}
+
+// Split splits the field around every sep.
+func (f InputField) Split(sep string) []InputField {
+	var fields []InputField
+	start := 0
+	for _, part := range strings.Split(f.Text, sep) {
+		fields = append(fields, f.slice(start, start+len(part)))
+		start += len(part) + len(sep)
+	}
+	return fields
+}
+
+// TrimPrefix returns the field without prefix, failing when it does not
+// start with it.
+func (f InputField) TrimPrefix(prefix string) (InputField, error) {
+	if !strings.HasPrefix(f.Text, prefix) {
+		return f, f.Errorf("want %q, got %q", prefix, f.Text)
+	}
+	return f.slice(len(prefix), len(f.Text)), nil
+}
+
+// Int parses the field as a decimal integer.
+func (f InputField) Int() (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(f.Text))
+	if err != nil {
+		return 0, f.Errorf("want an integer, got %q", f.Text)
+	}
+	return n, nil
+}
+
+// Ints parses every field as a decimal integer.
+func Ints(fields []InputField) ([]int, error) {
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := field.Int()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = n
+	}
+	return values, nil
+}
+
+func (f InputField) slice(from, to int) InputField {
+	return InputField{Text: f.Text[from:to], Line: f.Line, Column: f.Column + from}
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInputLines(t *testing.T) {
+	lines := InputLines("\n\np=0,4 v=3,-3\np=6,3  v=-1,x3\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if lines[0].Line != 3 {
+		t.Errorf("first line is number %d, want 3", lines[0].Line)
+	}
+
+	fields := lines[1].Fields()
+	if len(fields) != 2 || fields[1].Text != "v=-1,x3" || fields[1].Column != 8 {
+		t.Fatalf("fields = %+v", fields)
+	}
+
+	values, err := fields[1].TrimPrefix("v=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := values.Split(",")
+	if got, err := parts[0].Int(); err != nil || got != -1 {
+		t.Errorf("Int() = %d, %v, want -1", got, err)
+	}
+
+	_, err = Ints(parts)
+	var inputErr *InputError
+	if !errors.As(err, &inputErr) {
+		t.Fatalf("err = %v, want an *InputError", err)
+	}
+	if inputErr.Line != 4 || inputErr.Column != 13 {
+		t.Errorf("error at line %d, column %d, want line 4, column 13", inputErr.Line, inputErr.Column)
+	}
+	if want := `input line 4, column 13: want an integer, got "x3"`; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+}
+
+func TestInputFieldTrimPrefix(t *testing.T) {
+	line := InputLines("Program: 0,1,5")[0]
+	if _, err := line.TrimPrefix("Register"); err == nil {
+		t.Error("expected an error for a missing prefix")
+	}
+
+	program, err := line.TrimPrefix("Program: ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := Ints(program.Split(","))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 5}; !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v, want %v", values, want)
+	}
+}
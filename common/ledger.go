@@ -0,0 +1,136 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultLedgerPath is the ledger in the module root, see InModuleRoot.
+const DefaultLedgerPath = "ledger.json"
+
+// LedgerEntry is a single answer submitted to the site and its verdict.
+type LedgerEntry struct {
+	Answer      string    `json:"answer"`
+	Verdict     Verdict   `json:"verdict"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Ledger remembers every answer submitted per year, day and part, so known
+// bad answers are never sent twice.
+type Ledger struct {
+	path    string
+	Entries map[string][]LedgerEntry `json:"entries"`
+}
+
+func ledgerKey(year, day, part int) string {
+	return fmt.Sprintf("%d/day%02d/part%d", year, day, part)
+}
+
+// LoadLedger reads the ledger at path. A missing file is an empty ledger.
+func LoadLedger(path string) (*Ledger, error) {
+	ledger := &Ledger{
+		path:    path,
+		Entries: make(map[string][]LedgerEntry),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("parsing ledger %s: %w", path, err)
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = make(map[string][]LedgerEntry)
+	}
+
+	return ledger, nil
+}
+
+// Save writes the ledger back to the file it was loaded from.
+func (l *Ledger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(l.path, append(data, '\n'))
+}
+
+// History returns the answers submitted for a part, oldest first.
+func (l *Ledger) History(year, day, part int) []LedgerEntry {
+	return l.Entries[ledgerKey(year, day, part)]
+}
+
+// Record adds the verdict of a submission. Verdicts that say nothing about
+// the answer itself (rate limiting, already solved) are not recorded.
+func (l *Ledger) Record(year, day, part int, answer string, result *SubmitResult) {
+	switch result.Verdict {
+	case VerdictCorrect, VerdictTooHigh, VerdictTooLow, VerdictWrong:
+	default:
+		return
+	}
+
+	key := ledgerKey(year, day, part)
+	l.Entries[key] = append(l.Entries[key], LedgerEntry{
+		Answer:      strings.TrimSpace(answer),
+		Verdict:     result.Verdict,
+		SubmittedAt: time.Now().UTC(),
+	})
+}
+
+// CorrectAnswer returns the answer accepted by the site, if any.
+func (l *Ledger) CorrectAnswer(year, day, part int) (string, bool) {
+	for _, entry := range l.History(year, day, part) {
+		if entry.Verdict == VerdictCorrect {
+			return entry.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an error when submitting answer is pointless: the part is
+// already solved, the answer was rejected before, or it lies outside the
+// bounds learned from earlier "too high" and "too low" verdicts.
+func (l *Ledger) Check(year, day, part int, answer string) error {
+	answer = strings.TrimSpace(answer)
+
+	if correct, ok := l.CorrectAnswer(year, day, part); ok {
+		if correct == answer {
+			return fmt.Errorf("%s was already accepted", answer)
+		}
+		return fmt.Errorf("part already solved with %s", correct)
+	}
+
+	value, isNumber := new(big.Int).SetString(answer, 10)
+	for _, entry := range l.History(year, day, part) {
+		if entry.Answer == answer {
+			return fmt.Errorf("%s was already submitted and was %s", answer, entry.Verdict)
+		}
+		if !isNumber {
+			continue
+		}
+
+		bound, ok := new(big.Int).SetString(entry.Answer, 10)
+		if !ok {
+			continue
+		}
+		if entry.Verdict == VerdictTooHigh && value.Cmp(bound) >= 0 {
+			return fmt.Errorf("%s is not below %s, which was too high", answer, entry.Answer)
+		}
+		if entry.Verdict == VerdictTooLow && value.Cmp(bound) <= 0 {
+			return fmt.Errorf("%s is not above %s, which was too low", answer, entry.Answer)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+
+	ledger, err := LoadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Record(2024, 1, 1, "100", &SubmitResult{Verdict: VerdictTooLow})
+	ledger.Record(2024, 1, 1, "500", &SubmitResult{Verdict: VerdictTooHigh})
+	ledger.Record(2024, 1, 1, "300", &SubmitResult{Verdict: VerdictWrong})
+	ledger.Record(2024, 1, 1, "400", &SubmitResult{Verdict: VerdictRateLimited})
+	ledger.Record(2024, 1, 2, "42", &SubmitResult{Verdict: VerdictCorrect})
+	if err := ledger.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Everything below runs against the reloaded copy.
+	ledger, err = LoadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		part    int
+		answer  string
+		allowed bool
+	}{
+		{"within-bounds", 1, "250", true},
+		{"rate-limited-not-recorded", 1, "400", true},
+		{"known-wrong", 1, "300", false},
+		{"at-low-bound", 1, "100", false},
+		{"below-low-bound", 1, "99", false},
+		{"above-high-bound", 1, "501", false},
+		{"not-a-number", 1, "abc", true},
+		{"already-accepted", 2, "42", false},
+		{"already-solved", 2, "43", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ledger.Check(2024, 1, tt.part, tt.answer)
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("Check(%q) = %v, want allowed %v", tt.answer, err, tt.allowed)
+			}
+		})
+	}
+
+	if correct, ok := ledger.CorrectAnswer(2024, 1, 2); !ok || correct != "42" {
+		t.Errorf("CorrectAnswer() = %q, %v, want %q, true", correct, ok, "42")
+	}
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// LogLevel orders log messages by importance.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	// LevelOff logs nothing at all.
+	LevelOff
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	default:
+		return "off"
+	}
+}
+
+// Logger writes the messages of solvers at or above its level.
+type Logger struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level LogLevel
+}
+
+func NewLogger(out io.Writer, level LogLevel) *Logger {
+	return &Logger{out: out, level: level}
+}
+
+// Enabled reports whether messages at level are written.
+func (l *Logger) Enabled(level LogLevel) bool {
+	return level >= l.level && l.level != LevelOff
+}
+
+// Logf writes a message at level. Arguments are only formatted when the
+// message is written, so a Stringer rendering a whole grid costs nothing
+// when debug logging is off.
+func (l *Logger) Logf(level LogLevel, format string, args ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.out, "[%s] %s\n", level, strings.TrimRight(message, "\n"))
+}
+
+// logger is where Debugf, Infof and Warnf write. Solvers only warn unless the
+// runner is asked for more.
+var logger struct {
+	mu      sync.Mutex
+	current *Logger
+}
+
+func init() {
+	logger.current = NewLogger(os.Stderr, LevelWarn)
+}
+
+// SetLogger makes l the logger of solvers and returns a function restoring
+// the previous one.
+func SetLogger(l *Logger) (restore func()) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	previous := logger.current
+	logger.current = l
+	return func() {
+		logger.mu.Lock()
+		defer logger.mu.Unlock()
+		logger.current = previous
+	}
+}
+
+func currentLogger() *Logger {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	return logger.current
+}
+
+// LogEnabled reports whether messages at level are written, for solvers that
+// need more than a Debugf call to build a message.
+func LogEnabled(level LogLevel) bool {
+	return currentLogger().Enabled(level)
+}
+
+// Debugf logs details of a solve, shown with -debug.
+func Debugf(format string, args ...any) {
+	currentLogger().Logf(LevelDebug, format, args...)
+}
+
+// Infof logs progress of a solve, shown with -v.
+func Infof(format string, args ...any) {
+	currentLogger().Logf(LevelInfo, format, args...)
+}
+
+// Warnf logs something unexpected about a solve, always shown.
+func Warnf(format string, args ...any) {
+	currentLogger().Logf(LevelWarn, format, args...)
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+type countingStringer struct {
+	calls int
+}
+
+func (cs *countingStringer) String() string {
+	cs.calls++
+	return "grid"
+}
+
+func TestLogger(t *testing.T) {
+	var sb strings.Builder
+	defer SetLogger(NewLogger(&sb, LevelInfo))()
+
+	rendered := &countingStringer{}
+	Debugf("hidden %s", rendered)
+	Infof("step %d", 1)
+	Warnf("two\nlines\n")
+
+	if rendered.calls != 0 {
+		t.Errorf("String() called %d times for a disabled message", rendered.calls)
+	}
+	if want := "[info] step 1\n[warn] two\nlines\n"; sb.String() != want {
+		t.Errorf("log = %q, want %q", sb.String(), want)
+	}
+	if LogEnabled(LevelDebug) || !LogEnabled(LevelWarn) {
+		t.Error("LogEnabled does not follow the level")
+	}
+}
+
+func TestLoggerOff(t *testing.T) {
+	var sb strings.Builder
+	defer SetLogger(NewLogger(&sb, LevelOff))()
+
+	Warnf("nothing")
+	if sb.Len() != 0 {
+		t.Errorf("log = %q, want nothing", sb.String())
+	}
+}
@@ -15,3 +15,12 @@ func NumDigits(n int) int {
 	}
 	return count
 }
+
+// Mod returns a modulo m between 0 and m-1, also for negative a.
+func Mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
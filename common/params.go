@@ -0,0 +1,122 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Param declares a named parameter of a solver, for values that are not part
+// of the input, like the size of a grid that differs between the example and
+// the real input.
+type Param struct {
+	Name    string
+	Default string
+	Usage   string
+}
+
+// Params holds the value of every parameter of a solver, by name.
+type Params map[string]string
+
+// Int returns the value of name as an int.
+func (p Params) Int(name string) int {
+	value, ok := p[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown parameter %q", name))
+	}
+	return MustAtoi(value)
+}
+
+// String returns the value of name.
+func (p Params) String(name string) string {
+	value, ok := p[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown parameter %q", name))
+	}
+	return value
+}
+
+// ParameterizedSolver is implemented by solvers taking parameters. The
+// registered solver solves with the defaults, WithParams returns one solving
+// with the given values.
+type ParameterizedSolver interface {
+	ProblemSolver
+	Params() []Param
+	WithParams(params Params) ProblemSolver
+}
+
+// DefaultParams returns the default value of every parameter of ps.
+func DefaultParams(ps ParameterizedSolver) Params {
+	params := make(Params)
+	for _, param := range ps.Params() {
+		params[param.Name] = param.Default
+	}
+	return params
+}
+
+// ParamsOrDefault returns params, or the defaults of ps when params is nil,
+// as it is for the registered solver.
+func ParamsOrDefault(ps ParameterizedSolver, params Params) Params {
+	if params == nil {
+		return DefaultParams(ps)
+	}
+	return params
+}
+
+// ConfigureSolver applies overrides to the parameters of ps. Without
+// overrides ps is returned as is, solvers without parameters, unknown names
+// and values not of the type of the default are an error.
+func ConfigureSolver(ps ProblemSolver, overrides map[string]string) (ProblemSolver, error) {
+	if len(overrides) == 0 {
+		return ps, nil
+	}
+
+	parameterized, ok := ps.(ParameterizedSolver)
+	if !ok {
+		return nil, fmt.Errorf("solver takes no parameters, cannot set %s", formatParams(overrides))
+	}
+
+	params := DefaultParams(parameterized)
+	for name, value := range overrides {
+		def, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q, want one of %s", name, strings.Join(paramNames(parameterized), ", "))
+		}
+		if err := checkParamType(def, value); err != nil {
+			return nil, fmt.Errorf("invalid %s=%s: %w", name, value, err)
+		}
+		params[name] = value
+	}
+	return parameterized.WithParams(params), nil
+}
+
+// checkParamType fails when value cannot be read like the default of the
+// parameter, a word for an int parameter read with Params.Int.
+func checkParamType(def, value string) error {
+	if _, err := strconv.Atoi(def); err != nil {
+		return nil
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Errorf("want an integer like the default %s", def)
+	}
+	return nil
+}
+
+func paramNames(ps ParameterizedSolver) []string {
+	var names []string
+	for _, param := range ps.Params() {
+		names = append(names, param.Name)
+	}
+	return names
+}
+
+// formatParams renders params as sorted name=value pairs.
+func formatParams(params map[string]string) string {
+	var pairs []string
+	for name, value := range params {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
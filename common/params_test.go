@@ -0,0 +1,72 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+type paramsSolver struct {
+	params Params
+}
+
+func (s *paramsSolver) Params() []Param {
+	return []Param{{Name: "size", Default: "70"}}
+}
+
+func (s *paramsSolver) WithParams(params Params) ProblemSolver {
+	return &paramsSolver{params: params}
+}
+
+func (s *paramsSolver) SolvePart1(input string) Answer {
+	return StringAnswer(ParamsOrDefault(s, s.params).String("size"))
+}
+
+func (s *paramsSolver) SolvePart2(input string) Answer {
+	return StringAnswer(input)
+}
+
+type plainSolver struct{}
+
+func (s *plainSolver) SolvePart1(input string) Answer { return StringAnswer(input) }
+func (s *plainSolver) SolvePart2(input string) Answer { return StringAnswer(input) }
+
+func TestConfigureSolver(t *testing.T) {
+	registered := &paramsSolver{}
+	if got := registered.SolvePart1(""); got.String() != "70" {
+		t.Errorf("default size = %q, want %q", got, "70")
+	}
+
+	configured, err := ConfigureSolver(registered, map[string]string{"size": "6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := configured.SolvePart1(""); got.String() != "6" {
+		t.Errorf("configured size = %q, want %q", got, "6")
+	}
+
+	if _, err := ConfigureSolver(registered, map[string]string{"width": "6"}); err == nil || !strings.Contains(err.Error(), "size") {
+		t.Errorf("unknown parameter: err = %v, want one listing size", err)
+	}
+
+	if _, err := ConfigureSolver(registered, map[string]string{"size": "abc"}); err == nil || !strings.Contains(err.Error(), "integer") {
+		t.Errorf("size=abc: err = %v, want one asking for an integer", err)
+	}
+
+	if _, err := ConfigureSolver(&plainSolver{}, map[string]string{"size": "6"}); err == nil {
+		t.Error("expected an error for a solver without parameters")
+	}
+}
+
+func TestParseSolverFlagsParams(t *testing.T) {
+	flags, err := ParseSolverFlags([]string{"-set", "width=11", "-set", "height = 7"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatParams(flags.Params); got != "height=7, width=11" {
+		t.Errorf("params = %q", got)
+	}
+
+	if _, err := ParseSolverFlags([]string{"-set", "width"}, false); err == nil {
+		t.Error("expected an error for a parameter without a value")
+	}
+}
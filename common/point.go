@@ -0,0 +1,84 @@
+package common
+
+// Point is a position on a grid, or the offset between two positions.
+type Point struct {
+	Row, Col int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.Row + q.Row, p.Col + q.Col}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.Row - q.Row, p.Col - q.Col}
+}
+
+func (p Point) Scale(k int) Point {
+	return Point{p.Row * k, p.Col * k}
+}
+
+func (p Point) Neg() Point {
+	return Point{-p.Row, -p.Col}
+}
+
+// Manhattan returns the number of orthogonal steps between p and q.
+func (p Point) Manhattan(q Point) int {
+	return AbsInt(p.Row-q.Row) + AbsInt(p.Col-q.Col)
+}
+
+// Chebyshev returns the number of steps between p and q when diagonal steps
+// are allowed.
+func (p Point) Chebyshev(q Point) int {
+	rows, cols := AbsInt(p.Row-q.Row), AbsInt(p.Col-q.Col)
+	if rows > cols {
+		return rows
+	}
+	return cols
+}
+
+// RotateRight turns p a quarter clockwise around the origin, the way Up
+// turns into Right.
+func (p Point) RotateRight() Point {
+	return Point{p.Col, -p.Row}
+}
+
+// RotateLeft turns p a quarter counterclockwise around the origin.
+func (p Point) RotateLeft() Point {
+	return Point{-p.Col, p.Row}
+}
+
+// Within returns the points at most radius orthogonal steps away from p,
+// p included, row by row.
+func (p Point) Within(radius int) []Point {
+	var points []Point
+	for dr := -radius; dr <= radius; dr++ {
+		span := radius - AbsInt(dr)
+		for dc := -span; dc <= span; dc++ {
+			points = append(points, Point{p.Row + dr, p.Col + dc})
+		}
+	}
+	return points
+}
+
+// Cross returns the cross product of p and q as vectors, zero when they are
+// parallel.
+func (p Point) Cross(q Point) int {
+	return p.Row*q.Col - p.Col*q.Row
+}
+
+// Collinear reports whether p, q and r lie on one line.
+func (p Point) Collinear(q, r Point) bool {
+	return q.Sub(p).Cross(r.Sub(p)) == 0
+}
+
+// StepToward returns the smallest step from p that lands exactly on the
+// line to q every time, the offset to q divided by the GCD of its rows and
+// columns. Walking it from p visits every point of the line on the grid.
+func (p Point) StepToward(q Point) Point {
+	d := q.Sub(p)
+	g := GCD(AbsInt(d.Row), AbsInt(d.Col))
+	if g == 0 {
+		return Point{}
+	}
+	return Point{d.Row / g, d.Col / g}
+}
@@ -0,0 +1,72 @@
+package common
+
+import "testing"
+
+func TestPointArithmetic(t *testing.T) {
+	p, q := Point{1, 2}, Point{4, -2}
+
+	if got := p.Add(q); got != (Point{5, 0}) {
+		t.Errorf("Add = %v", got)
+	}
+	if got := q.Sub(p); got != (Point{3, -4}) {
+		t.Errorf("Sub = %v", got)
+	}
+	if got := p.Scale(3).Neg(); got != (Point{-3, -6}) {
+		t.Errorf("Scale(3).Neg() = %v", got)
+	}
+	if got := p.Manhattan(q); got != 7 {
+		t.Errorf("Manhattan = %d, want 7", got)
+	}
+	if got := p.Chebyshev(q); got != 4 {
+		t.Errorf("Chebyshev = %d, want 4", got)
+	}
+}
+
+func TestPointRotate(t *testing.T) {
+	for _, d := range Directions {
+		if got, want := d.Delta().RotateRight(), d.TurnRight().Delta(); got != want {
+			t.Errorf("%v rotated right = %v, want %v", d, got, want)
+		}
+		if got, want := d.Delta().RotateLeft(), d.TurnLeft().Delta(); got != want {
+			t.Errorf("%v rotated left = %v, want %v", d, got, want)
+		}
+	}
+}
+
+func TestPointWithin(t *testing.T) {
+	center := Point{5, 5}
+	points := center.Within(2)
+	if len(points) != 13 {
+		t.Fatalf("Within(2) has %d points, want 13", len(points))
+	}
+	seen := map[Point]bool{}
+	for _, p := range points {
+		if d := center.Manhattan(p); d > 2 {
+			t.Errorf("%v is %d away", p, d)
+		}
+		seen[p] = true
+	}
+	if len(seen) != 13 || !seen[center] {
+		t.Errorf("Within(2) has duplicates or misses the center: %v", points)
+	}
+}
+
+func TestPointLines(t *testing.T) {
+	a, b := Point{1, 1}, Point{5, 7}
+
+	if !a.Collinear(b, Point{3, 4}) {
+		t.Error("midpoint is not collinear")
+	}
+	if a.Collinear(b, Point{3, 5}) {
+		t.Error("off the line point is collinear")
+	}
+	if got := a.StepToward(b); got != (Point{2, 3}) {
+		t.Errorf("StepToward = %v, want {2 3}", got)
+	}
+	if got := a.StepToward(a); got != (Point{}) {
+		t.Errorf("StepToward(itself) = %v, want {0 0}", got)
+	}
+	if got := (Point{2, 0}).Cross(Point{0, 3}); got != 6 {
+		t.Errorf("Cross = %d, want 6", got)
+	}
+}
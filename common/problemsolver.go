@@ -1,73 +1,396 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
-	"net/http/cookiejar"
-	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 type ProblemRunner interface {
-	Run()
+	Run(ctx context.Context) ([]*RunResult, error)
 }
 
 type baseProblemRunnerImpl struct {
-	flags  *ProblemSolverFlags
-	Solver ProblemSolver
-	input  string
+	flags    *ProblemSolverFlags
+	solution *Solution
+	// stdin holds standard input once read, it can only be read once but
+	// every part needs it.
+	stdin *string
+	// commit is the commit history entries are recorded at, looked up
+	// once.
+	commit string
 }
 
-func NewProblemRunner(flags *ProblemSolverFlags, solver ProblemSolver, input string) ProblemRunner {
+func NewProblemRunner(flags *ProblemSolverFlags, solution *Solution) ProblemRunner {
 	return &baseProblemRunnerImpl{
-		flags:  flags,
-		Solver: solver,
-		input:  input,
+		flags:    flags,
+		solution: solution,
 	}
 }
 
-func RunFromSolver(solver ProblemSolver, input string) {
-	flags := MustParseSolverFlags(os.Args[1:], true)
-	runner := NewProblemRunner(
-		flags,
-		solver,
-		input,
-	)
-	runner.Run()
+// Run solves every part selected by the flags, in order. It stops at the
+// first part that fails or outlives the timeout, or when ctx is done.
+func (pr *baseProblemRunnerImpl) Run(ctx context.Context) ([]*RunResult, error) {
+	parts := pr.flags.Parts
+	if len(parts) == 0 {
+		parts = []int{1}
+	}
+
+	solver, err := ConfigureSolver(pr.solution.Solver, pr.flags.Params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pr.solution, err)
+	}
+
+	restoreLogger, err := pr.useLogger()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pr.solution, err)
+	}
+	defer restoreLogger()
+
+	if pr.flags.PprofAddr != "" {
+		url, stopProfiler, err := serveProfiler(pr.flags.PprofAddr)
+		if err != nil {
+			return nil, err
+		}
+		defer stopProfiler()
+		fmt.Fprintln(os.Stderr, "Serving pprof on", url)
+	}
+
+	var results []*RunResult
+	for _, part := range parts {
+		result, err := pr.runPart(ctx, solver, part)
+		if err != nil {
+			return results, fmt.Errorf("%s, part %d: %w", pr.solution, part, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// useLogger points the log of solvers at stderr, or the log file of the day
+// with -logdir, at the level of the flags.
+func (pr *baseProblemRunnerImpl) useLogger() (func(), error) {
+	if pr.flags.LogDir == "" {
+		return SetLogger(NewLogger(os.Stderr, pr.flags.LogLevel())), nil
+	}
+
+	path := filepath.Join(pr.flags.LogDir, Itoa(pr.solution.Year), fmt.Sprintf("day%02d.log", pr.solution.Day))
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	restore := SetLogger(NewLogger(file, pr.flags.LogLevel()))
+	return func() {
+		restore()
+		file.Close()
+	}, nil
 }
 
-func (pr *baseProblemRunnerImpl) Run() {
-	fmt.Println("Solving...")
-	var result string
-	if pr.flags.Part == 1 {
-		result = pr.Solver.SolvePart1(pr.input)
+func (pr *baseProblemRunnerImpl) runPart(ctx context.Context, solver ProblemSolver, part int) (*RunResult, error) {
+	input, source, err := pr.input(part)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pr.flags.Json {
+		var details []string
+		if source != "" {
+			details = append(details, source)
+		}
+		if len(pr.flags.Params) > 0 {
+			details = append(details, formatParams(pr.flags.Params))
+		}
+		header := fmt.Sprintf("%s, part %d", pr.solution, part)
+		if len(details) > 0 {
+			header += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+		}
+		fmt.Println(header)
+		fmt.Println("Solving...")
+	}
+
+	if pr.flags.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pr.flags.Timeout)
+		defer cancel()
+	}
+
+	stopProfiling, err := pr.startProfiling(part)
+	if err != nil {
+		return nil, err
+	}
+	var solveErr error
+	answer, stats := measure(func() Answer {
+		answer, err := SolveContext(ctx, solver, part, input)
+		solveErr = err
+		return answer
+	})
+	if err := stopProfiling(); err != nil {
+		return nil, fmt.Errorf("writing profiles: %w", err)
+	}
+	switch {
+	case errors.Is(solveErr, context.DeadlineExceeded):
+		return nil, fmt.Errorf("no result within %v%s: %w", pr.flags.Timeout, cancellationHint(solver), solveErr)
+	case solveErr != nil:
+		return nil, solveErr
+	}
+
+	runResult := &RunResult{
+		Year:        pr.solution.Year,
+		Day:         pr.solution.Day,
+		Part:        part,
+		Input:       source,
+		Params:      pr.flags.Params,
+		Result:      answer.String(),
+		Kind:        answer.Kind,
+		Diagnostics: answer.Diagnostics,
+		RunStats:    stats,
+	}
+
+	// Timings of other inputs say nothing about the history of the day, nor
+	// do the ones inflated by profiling or logging.
+	if source == "" && len(pr.flags.Params) == 0 && !pr.flags.Instrumented() {
+		pr.recordHistory(runResult)
+	}
+
+	if pr.flags.Json {
+		data, err := json.Marshal(runResult)
+		if err != nil {
+			return nil, fmt.Errorf("encoding result: %w", err)
+		}
+		fmt.Println(string(data))
+		return runResult, nil
+	}
+
+	if strings.Contains(answer.String(), "\n") {
+		fmt.Printf("Result:\n%s\n", answer)
+		if letters, err := answer.Submission(); err == nil {
+			fmt.Println("Reads as:", letters)
+		}
 	} else {
-		result = pr.Solver.SolvePart2(pr.input)
+		fmt.Println("Result:", answer)
+	}
+	for _, diagnostic := range answer.Diagnostics {
+		fmt.Println("\t" + diagnostic)
+	}
+	// Answers are only known for the real input and defaults.
+	if source == "" && len(pr.flags.Params) == 0 {
+		pr.compareWithRecorded(part, answer)
+	}
+	fmt.Println(stats.Footer())
+
+	return runResult, nil
+}
+
+// input returns the input to solve part with and where it comes from, empty
+// for the embedded input.
+func (pr *baseProblemRunnerImpl) input(part int) (string, string, error) {
+	switch {
+	case pr.flags.InputPath == "-":
+		if pr.stdin == nil {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return "", "", fmt.Errorf("reading stdin: %w", err)
+			}
+			input := trimInput(string(data))
+			pr.stdin = &input
+		}
+		return *pr.stdin, "stdin", nil
+	case pr.flags.InputPath != "":
+		data, err := os.ReadFile(pr.flags.InputPath)
+		if err != nil {
+			return "", "", err
+		}
+		return trimInput(string(data)), pr.flags.InputPath, nil
+	case pr.flags.Example:
+		return readExampleInput(pr.solution, part)
+	default:
+		input, err := pr.solution.EmbeddedInput()
+		return input, "", err
+	}
+}
+
+// exampleInputNames are the files holding the example of a day, most
+// specific first.
+var exampleInputNames = []string{
+	"example_part%d_input.txt",
+	"example_input.txt",
+}
+
+// readExampleInput reads the example input of part from the directory of the
+// solution, relative to the module root.
+func readExampleInput(solution *Solution, part int) (string, string, error) {
+	root, err := FindModuleRoot()
+	if err != nil {
+		return "", "", err
+	}
+	dir := filepath.Join(root, Itoa(solution.Year), fmt.Sprintf("day%02d", solution.Day))
+
+	// An example under another name would be passed over for the one of
+	// part 1 without a word.
+	if err := checkExampleInputNames(dir); err != nil {
+		return "", "", err
+	}
+
+	for _, name := range exampleInputNames {
+		if strings.Contains(name, "%d") {
+			name = fmt.Sprintf(name, part)
+		}
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		return trimInput(string(data)), rel, nil
+	}
+	return "", "", fmt.Errorf("no example input for part %d in %s", part, dir)
+}
+
+// checkExampleInputNames fails on example files in dir that match none of
+// exampleInputNames.
+func checkExampleInputNames(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "example*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if !isExampleInputName(filepath.Base(path)) {
+			return fmt.Errorf("unknown example input %s, name it %s or %s",
+				path, fmt.Sprintf(exampleInputNames[0], 2), exampleInputNames[1])
+		}
+	}
+	return nil
+}
+
+func isExampleInputName(name string) bool {
+	for _, pattern := range exampleInputNames {
+		if !strings.Contains(pattern, "%d") {
+			if name == pattern {
+				return true
+			}
+			continue
+		}
+		var part int
+		if _, err := fmt.Sscanf(name, pattern, &part); err == nil && fmt.Sprintf(pattern, part) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// trimInput drops the final newline, as every day does with its embedded
+// input.
+func trimInput(input string) string {
+	return strings.TrimRight(input, "\n")
+}
+
+// recordHistory appends the timing of result to the history file, a failure
+// to do so is only worth a warning.
+func (pr *baseProblemRunnerImpl) recordHistory(result *RunResult) {
+	if pr.flags.HistoryPath == "" {
+		return
+	}
+	if pr.commit == "" {
+		pr.commit = CurrentCommit()
+	}
+
+	err := AppendHistory(pr.flags.HistoryPath, HistoryEntry{
+		Commit:   pr.commit,
+		Time:     time.Now().UTC(),
+		Source:   HistorySourceRun,
+		Year:     result.Year,
+		Day:      result.Day,
+		Part:     result.Part,
+		Duration: result.Total,
+		Allocs:   result.Allocs,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to record history:", err)
+	}
+}
+
+// compareWithRecorded reports whether answer matches the answer the site
+// accepted before, as recorded in the answers file of the day or, for
+// answers submitted before it had one, the ledger.
+func (pr *baseProblemRunnerImpl) compareWithRecorded(part int, answer Answer) {
+	correct, ok, err := pr.recordedAnswer(part)
+	switch {
+	case err != nil:
+		fmt.Println("\tFailed to read the recorded answer:", err)
+	case !ok:
+		fmt.Println("No correct answer recorded yet")
+	case answer.Matches(correct):
+		fmt.Println("Matches the recorded correct answer")
+	default:
+		fmt.Println("Does NOT match the recorded correct answer:", correct)
+	}
+}
+
+func (pr *baseProblemRunnerImpl) recordedAnswer(part int) (string, bool, error) {
+	year, day := pr.solution.Year, pr.solution.Day
+	if root, err := FindModuleRoot(); err == nil {
+		answers, err := ReadAnswers(AnswersPath(root, year, day))
+		if err != nil {
+			return "", false, err
+		}
+		if correct, ok := answers[part]; ok {
+			return correct, true, nil
+		}
+	}
+
+	if pr.flags.LedgerPath == "" {
+		return "", false, nil
+	}
+	ledger, err := LoadLedger(pr.flags.LedgerPath)
+	if err != nil {
+		return "", false, err
 	}
-	fmt.Println("Result:", result)
+	correct, ok := ledger.CorrectAnswer(year, day, part)
+	return correct, ok, nil
 }
 
 type ProblemSolver interface {
-	SolvePart1(input string) string
-	SolvePart2(input string) string
+	SolvePart1(input string) Answer
+	SolvePart2(input string) Answer
 }
 
 type ProblemReader interface {
 	GetInput() (string, error)
 	MustGetInput() string
+	GetDescription() (string, error)
+	MustGetDescription() string
+	GetPuzzlePage() (string, error)
 }
 
 type baseProblemReaderImpl struct {
-	flags      *InputGetterFlags
-	httpClient *http.Client
+	flags  *InputGetterFlags
+	client *siteClient
 }
 
-func NewProblemReader(flags *InputGetterFlags) ProblemReader {
-	pr := baseProblemReaderImpl{flags: flags}
-	pr.initHttpClient()
-	return &pr
+func NewProblemReader(flags *InputGetterFlags) (ProblemReader, error) {
+	client, err := newSiteClient(flags)
+	if err != nil {
+		return nil, err
+	}
+	return &baseProblemReaderImpl{
+		flags:  flags,
+		client: client,
+	}, nil
 }
 
 func (pr *baseProblemReaderImpl) MustGetInput() string {
@@ -77,78 +400,96 @@ func (pr *baseProblemReaderImpl) MustGetInput() string {
 }
 
 func (pr *baseProblemReaderImpl) GetInput() (string, error) {
+	root, err := pr.flags.CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	cachePath := filepath.Join(root, pr.flags.CacheKey())
+
 	// Check if cached data exists.
-	if data, err := os.ReadFile(pr.flags.CacheKey()); err == nil {
-		fmt.Println("\tUsing cached input")
-		return string(data), nil
+	if !pr.flags.Refresh {
+		data, err := readCachedInput(cachePath)
+		switch {
+		case err == nil:
+			fmt.Println("\tUsing cached input")
+			return data, nil
+		case errors.Is(err, ErrInvalidCache):
+			fmt.Println("\tIgnoring cached input:", err)
+		case !errors.Is(err, os.ErrNotExist):
+			return "", err
+		}
 	}
 
-	// If not, get data from the website.
+	// If not, get data from the website. Error pages never get here, so
+	// they are never cached.
 	result, err := pr.GetInputFromWebsite()
 	if err != nil {
 		return "", err
 	}
+	if err := validateInput(result); err != nil {
+		return "", fmt.Errorf("fetched input: %w", err)
+	}
 
 	// Cache the data.
-	os.WriteFile(pr.flags.CacheKey(), []byte(result), 0644)
+	if err := writeCachedInput(cachePath, result, pr.flags.InputUrl()); err != nil {
+		return "", fmt.Errorf("caching input: %w", err)
+	}
 
 	return result, nil
 }
 
-func (pr *baseProblemReaderImpl) GetInputFromWebsite() (string, error) {
-	resp, err := pr.httpClient.Get(pr.flags.InputUrl())
+func (pr *baseProblemReaderImpl) MustGetDescription() string {
+	result, err := pr.GetDescription()
+	CheckErr(err, "Failed to get description")
+	return result
+}
+
+// GetDescription fetches the puzzle page and saves its description as
+// Markdown. It is never served from cache, since part 2 only shows up
+// once part 1 is solved.
+func (pr *baseProblemReaderImpl) GetDescription() (string, error) {
+	page, err := pr.GetPuzzlePage()
 	if err != nil {
 		return "", err
 	}
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	result, err := PuzzleToMarkdown(page, pr.flags.PuzzleUrl())
 	if err != nil {
 		return "", err
 	}
 
-	return string(body), nil
-}
-
-func (pr *baseProblemReaderImpl) initHttpClient() {
-	// Create an HTTP client with a cookie jar
-	jar, err := cookiejar.New(nil)
-	CheckErr(err, "Failed to create cookie jar")
-
-	// Read cookies from a file
-	cookies, err := readCookies(pr.flags.CookieFilePath)
-	CheckErr(err, "Failed to read cookies")
+	root, err := pr.flags.CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(filepath.Join(root, pr.flags.DescriptionKey()), []byte(result)); err != nil {
+		return "", err
+	}
 
-	// Set cookies for the client
-	baseUrl, err := url.Parse(pr.flags.BaseUrl)
-	CheckErr(err, "Failed to parse base url")
-	jar.SetCookies(baseUrl, cookies)
+	return result, nil
+}
 
-	pr.httpClient = &http.Client{
-		Jar: jar,
+// GetPuzzlePage returns the raw HTML of the puzzle page.
+func (pr *baseProblemReaderImpl) GetPuzzlePage() (string, error) {
+	if err := pr.awaitUnlock(); err != nil {
+		return "", err
 	}
+	return pr.client.get(pr.flags.PuzzleUrl())
 }
 
-// readCookies reads cookies from a file and returns them as a slice of *http.Cookie.
-func readCookies(filepath string) ([]*http.Cookie, error) {
-	content, err := os.ReadFile(filepath)
-	if err != nil {
-		return nil, err
+func (pr *baseProblemReaderImpl) GetInputFromWebsite() (string, error) {
+	if err := pr.awaitUnlock(); err != nil {
+		return "", err
 	}
+	return pr.client.get(pr.flags.InputUrl())
+}
 
-	lines := strings.Split(string(content), "\n")
-	var cookies []*http.Cookie
-
-	for _, line := range lines {
-		parts := strings.Split(line, "\t")
-		if len(parts) == 7 {
-			cookies = append(cookies, &http.Cookie{
-				Name:  parts[5],
-				Value: parts[6],
-				// Add other cookie attributes as needed, e.g., Domain, Path, etc.
-			})
-		}
+// awaitUnlock waits for the puzzle to unlock when asked to, otherwise
+// fails early instead of requesting a page that does not exist yet.
+func (pr *baseProblemReaderImpl) awaitUnlock() error {
+	if pr.flags.Wait {
+		WaitForUnlock(pr.flags.Year, pr.flags.Day)
+		return nil
 	}
-
-	return cookies, nil
+	return CheckUnlocked(pr.flags.Year, pr.flags.Day, time.Now())
 }
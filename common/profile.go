@@ -0,0 +1,126 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"runtime/trace"
+)
+
+// profileFile returns the path of a profile of part in dir, like
+// DIR/2024/day16-part2.cpu.pprof, creating its directory.
+func profileFile(dir string, solution *Solution, part int, ext string) (string, error) {
+	path := filepath.Join(dir, Itoa(solution.Year), fmt.Sprintf("day%02d-part%d.%s", solution.Day, part, ext))
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// startProfiling starts the CPU profile and execution trace of part asked
+// for by the flags. The returned function stops them and writes the memory
+// profile, it must be called once the solve is done.
+func (pr *baseProblemRunnerImpl) startProfiling(part int) (stop func() error, err error) {
+	var stops []func() error
+	stop = func() error {
+		var errs []error
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+		return errors.Join(errs...)
+	}
+	defer func() {
+		if err != nil {
+			stop()
+		}
+	}()
+
+	if pr.flags.CPUProfileDir != "" {
+		file, err := pr.createProfile(pr.flags.CPUProfileDir, part, "cpu.pprof")
+		if err != nil {
+			return nil, err
+		}
+		if err := runtimepprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("cpu profile: %w", err)
+		}
+		stops = append(stops, func() error {
+			runtimepprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if pr.flags.TraceDir != "" {
+		file, err := pr.createProfile(pr.flags.TraceDir, part, "trace")
+		if err != nil {
+			return nil, err
+		}
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("trace: %w", err)
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+
+	if pr.flags.MemProfileDir != "" {
+		// Written last, the allocations of the solve are all in by then.
+		stops = append([]func() error{func() error {
+			file, err := pr.createProfile(pr.flags.MemProfileDir, part, "mem.pprof")
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			runtime.GC()
+			return runtimepprof.Lookup("allocs").WriteTo(file, 0)
+		}}, stops...)
+	}
+
+	return stop, nil
+}
+
+func (pr *baseProblemRunnerImpl) createProfile(dir string, part int, ext string) (*os.File, error) {
+	path, err := profileFile(dir, pr.solution, part, ext)
+	if err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
+// serveProfiler serves the net/http/pprof handlers on addr until the
+// returned function is called, and returns their URL. An address without a
+// host, like :6060, is bound to localhost only.
+func serveProfiler(addr string) (url string, stop func(), err error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", nil, fmt.Errorf("-pprof: %w", err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return "", nil, fmt.Errorf("-pprof: %w", err)
+	}
+
+	// A mux of our own, days importing this package should not get the
+	// handlers on http.DefaultServeMux.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	return fmt.Sprintf("http://%s/debug/pprof/", listener.Addr()), func() { server.Close() }, nil
+}
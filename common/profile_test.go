@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunnerProfiles(t *testing.T) {
+	dir := t.TempDir()
+	flags := &ProblemSolverFlags{
+		Parts:         []int{1, 2},
+		CPUProfileDir: dir,
+		MemProfileDir: dir,
+		TraceDir:      dir,
+	}
+	solution := &Solution{Year: 2024, Day: 3, Solver: &plainSolver{}, Input: "input"}
+
+	if _, err := NewProblemRunner(flags, solution).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{
+		"day03-part1.cpu.pprof", "day03-part1.mem.pprof", "day03-part1.trace",
+		"day03-part2.cpu.pprof", "day03-part2.mem.pprof", "day03-part2.trace",
+	} {
+		info, err := os.Stat(filepath.Join(dir, "2024", name))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", name)
+		}
+	}
+}
+
+func TestServeProfiler(t *testing.T) {
+	url, stop, err := serveProfiler("localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET %s = %s", url, resp.Status)
+	}
+
+	if _, _, err := serveProfiler("6060"); err == nil || !strings.Contains(err.Error(), "-pprof") {
+		t.Errorf("serveProfiler(6060) = %v, want an address error", err)
+	}
+}
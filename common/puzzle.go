@@ -0,0 +1,289 @@
+package common
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// htmlNode is a minimal element tree, enough to render puzzle descriptions.
+type htmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+}
+
+// parseHTMLFragment builds a tree from a well formed HTML fragment using the
+// lenient mode of the standard library XML decoder.
+func parseHTMLFragment(fragment string) (*htmlNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(fragment))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &htmlNode{}
+	stack := []*htmlNode{root}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &htmlNode{name: strings.ToLower(t.Name.Local), attrs: make(map[string]string)}
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &htmlNode{text: string(t)})
+		}
+	}
+
+	return root, nil
+}
+
+// textContent returns all text below the node, as is.
+func (n *htmlNode) textContent() string {
+	if n.name == "" && len(n.children) == 0 {
+		return n.text
+	}
+	var sb strings.Builder
+	for _, child := range n.children {
+		sb.WriteString(child.textContent())
+	}
+	return sb.String()
+}
+
+func (n *htmlNode) hasDescendant(name string) bool {
+	for _, child := range n.children {
+		if child.name == name || child.hasDescendant(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findAll returns the elements named name below n in document order.
+func (n *htmlNode) findAll(name string) []*htmlNode {
+	result := make([]*htmlNode, 0)
+	for _, child := range n.children {
+		if child.name == name {
+			result = append(result, child)
+		}
+		result = append(result, child.findAll(name)...)
+	}
+	return result
+}
+
+// findArticles returns the raw HTML of every <article class="day-desc"> block
+// of a puzzle page. There is one per part unlocked so far.
+func findArticles(page string) []string {
+	const open = `<article class="day-desc">`
+	const close = `</article>`
+
+	articles := make([]string, 0, 2)
+	for {
+		start := strings.Index(page, open)
+		if start == -1 {
+			break
+		}
+		end := strings.Index(page[start:], close)
+		if end == -1 {
+			break
+		}
+		articles = append(articles, page[start:start+end+len(close)])
+		page = page[start+end+len(close):]
+	}
+	return articles
+}
+
+// PuzzleToMarkdown converts the descriptions of a puzzle page to Markdown.
+// Relative links are resolved against baseUrl.
+func PuzzleToMarkdown(page string, baseUrl string) (string, error) {
+	base, err := url.Parse(baseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	articles := findArticles(page)
+	if len(articles) == 0 {
+		return "", errors.New("no puzzle description found in page")
+	}
+
+	renderer := markdownRenderer{base: base}
+	for _, article := range articles {
+		root, err := parseHTMLFragment(article)
+		if err != nil {
+			return "", fmt.Errorf("parsing puzzle description: %w", err)
+		}
+		renderer.renderBlocks(root)
+	}
+
+	return strings.TrimSpace(renderer.sb.String()) + "\n", nil
+}
+
+type markdownRenderer struct {
+	base *url.URL
+	sb   strings.Builder
+}
+
+// renderBlocks renders the block level children of n, each separated by an
+// empty line.
+func (mr *markdownRenderer) renderBlocks(n *htmlNode) {
+	for _, child := range n.children {
+		switch child.name {
+		case "":
+			// Whitespace between blocks.
+			if text := strings.TrimSpace(child.text); text != "" {
+				mr.sb.WriteString(text + "\n\n")
+			}
+		case "article", "div", "main":
+			mr.renderBlocks(child)
+		case "h2":
+			mr.sb.WriteString("## " + mr.renderInline(child) + "\n\n")
+		case "p":
+			mr.sb.WriteString(mr.renderInline(child) + "\n\n")
+		case "pre":
+			code := strings.TrimRight(child.textContent(), "\n")
+			mr.sb.WriteString("```\n" + code + "\n```\n\n")
+		case "ul", "ol":
+			for _, item := range child.children {
+				if item.name == "li" {
+					mr.sb.WriteString("- " + mr.renderInline(item) + "\n")
+				}
+			}
+			mr.sb.WriteString("\n")
+		default:
+			mr.sb.WriteString(mr.renderInline(child) + "\n\n")
+		}
+	}
+}
+
+// renderInline renders the children of n as a single paragraph.
+func (mr *markdownRenderer) renderInline(n *htmlNode) string {
+	return strings.TrimSpace(collapseSpaces(mr.renderChildren(n)))
+}
+
+func (mr *markdownRenderer) renderChildren(n *htmlNode) string {
+	var sb strings.Builder
+	for _, child := range n.children {
+		sb.WriteString(mr.renderInlineNode(child))
+	}
+	return sb.String()
+}
+
+func (mr *markdownRenderer) renderInlineNode(n *htmlNode) string {
+	switch n.name {
+	case "":
+		return escapeMarkdown(n.text)
+	case "code":
+		code := "`" + collapseSpaces(n.textContent()) + "`"
+		// Emphasis does not work inside code spans, so it goes around them.
+		if n.hasDescendant("em") {
+			return "**" + code + "**"
+		}
+		return code
+	case "em":
+		return "**" + mr.renderInline(n) + "**"
+	case "a":
+		href := n.attrs["href"]
+		if ref, err := url.Parse(href); err == nil {
+			href = mr.base.ResolveReference(ref).String()
+		}
+		return "[" + mr.renderInline(n) + "](" + href + ")"
+	default:
+		return mr.renderChildren(n)
+	}
+}
+
+// collapseSpaces folds runs of whitespace, as a browser would.
+func collapseSpaces(s string) string {
+	return spaceRegexp.ReplaceAllString(s, " ")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// PuzzleExample is the example input of one part of a puzzle and the answer
+// the description gives for it.
+type PuzzleExample struct {
+	Part   int
+	Input  string
+	Answer string
+}
+
+// ExtractExamples guesses the example of every part on a puzzle page: the
+// first large <pre><code> block is the input and the last <code><em> is the
+// answer. A part without its own example block reuses the previous one.
+func ExtractExamples(page string) ([]PuzzleExample, error) {
+	articles := findArticles(page)
+	if len(articles) == 0 {
+		return nil, errors.New("no puzzle description found in page")
+	}
+
+	examples := make([]PuzzleExample, 0, len(articles))
+	previousInput := ""
+	for i, article := range articles {
+		root, err := parseHTMLFragment(article)
+		if err != nil {
+			return nil, fmt.Errorf("parsing puzzle description: %w", err)
+		}
+
+		example := PuzzleExample{Part: i + 1, Input: previousInput}
+		if input := pickExampleInput(root.findAll("pre")); input != "" {
+			example.Input = input
+		}
+
+		for _, code := range root.findAll("code") {
+			if code.hasDescendant("em") {
+				example.Answer = strings.TrimSpace(code.textContent())
+			}
+		}
+
+		examples = append(examples, example)
+		previousInput = example.Input
+	}
+
+	return examples, nil
+}
+
+// pickExampleInput returns the first multi-line block, or the longest one
+// when every block is a single line.
+func pickExampleInput(blocks []*htmlNode) string {
+	longest := ""
+	for _, block := range blocks {
+		content := block.textContent()
+		if strings.Count(strings.TrimSpace(content), "\n") > 0 {
+			return content
+		}
+		if len(content) > len(longest) {
+			longest = content
+		}
+	}
+	return longest
+}
@@ -0,0 +1,80 @@
+package common
+
+import "testing"
+
+func TestPuzzleToMarkdown(t *testing.T) {
+	const page = `<!DOCTYPE html>
+<html lang="en-us">
+<head><script>if (a < b && c) {}</script></head>
+<body>
+<main>
+<article class="day-desc"><h2>--- Day 1: Historian Hysteria ---</h2><p>The <em>Chief Historian</em> is always present.</p>
+<p>For example:</p>
+<pre><code>3   4
+4   3
+</code></pre>
+<ul>
+<li>The smallest number in the left list is <code>1</code>.</li>
+<li>See the <a href="/2024/about">about page</a>&nbsp;for more.</li>
+</ul>
+<p>In the example above, this is <code>2 + 1 + 0</code>, a total distance of <code><em>11</em></code>!</p>
+</article>
+<p>Your puzzle answer was <code>1666427</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>Calculate a total <span title="snake_case">similarity score</span> by adding up each number.</p>
+</article>
+</main>
+</body>
+</html>`
+
+	want := "## --- Day 1: Historian Hysteria ---\n\n" +
+		"The **Chief Historian** is always present.\n\n" +
+		"For example:\n\n" +
+		"```\n3   4\n4   3\n```\n\n" +
+		"- The smallest number in the left list is `1`.\n" +
+		"- See the [about page](https://adventofcode.com/2024/about) for more.\n\n" +
+		"In the example above, this is `2 + 1 + 0`, a total distance of **`11`**!\n\n" +
+		"## --- Part Two ---\n\n" +
+		"Calculate a total similarity score by adding up each number.\n"
+
+	got, err := PuzzleToMarkdown(page, "https://adventofcode.com/2024/day/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("PuzzleToMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExtractExamples(t *testing.T) {
+	const page = `<main>
+<article class="day-desc"><h2>--- Day 9: Disk Fragmenter ---</h2>
+<p>For example, the disk map <code>12345</code> would represent a one-block file:</p>
+<pre><code>0..111....22222</code></pre>
+<p>The actual example is:</p>
+<pre><code>2333133121414131402
+</code></pre>
+<p>The first few positions give <code>0 * 0 = 0</code>, so the checksum is <code><em>1928</em></code>.</p>
+</article>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>The process of updating the filesystem checksum is the same; now, this example's checksum would be <code><em>2858</em></code>.</p>
+</article>
+</main>`
+
+	want := []PuzzleExample{
+		{Part: 1, Input: "2333133121414131402\n", Answer: "1928"},
+		{Part: 2, Input: "2333133121414131402\n", Answer: "2858"},
+	}
+
+	got, err := ExtractExamples(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractExamples() returned %d examples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractExamples()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
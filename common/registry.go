@@ -0,0 +1,91 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Solution ties a ProblemSolver to the puzzle it solves and its embedded input.
+type Solution struct {
+	Year   int
+	Day    int
+	Solver ProblemSolver
+	// Input is empty until the input.txt of the day is fetched, use
+	// EmbeddedInput to solve it.
+	Input string
+}
+
+// ErrNoInput is returned when solving the embedded input of a day whose
+// input.txt is still empty.
+var ErrNoInput = errors.New("no input")
+
+// EmbeddedInput returns the input embedded in the day package, ErrNoInput
+// when it was never fetched.
+func (s *Solution) EmbeddedInput() (string, error) {
+	if s.Input == "" {
+		return "", fmt.Errorf("%w, fetch it with make input YEAR=%d DAY=%02d", ErrNoInput, s.Year, s.Day)
+	}
+	return s.Input, nil
+}
+
+func (s *Solution) String() string {
+	return fmt.Sprintf("%d day %02d", s.Year, s.Day)
+}
+
+type solutionKey struct {
+	year, day int
+}
+
+var registry = make(map[solutionKey]*Solution)
+
+// Register adds a solver to the registry. Every day package calls this from
+// its init function, so importing the package is enough to make it runnable.
+// The input may be empty, only solving it fails then.
+func Register(year, day int, solver ProblemSolver, input string) {
+	key := solutionKey{year, day}
+	if _, ok := registry[key]; ok {
+		panic(fmt.Sprintf("solution for %d day %02d registered twice", year, day))
+	}
+	registry[key] = &Solution{
+		Year:   year,
+		Day:    day,
+		Solver: solver,
+		Input:  input,
+	}
+}
+
+// Lookup returns the registered solution for the given year and day.
+func Lookup(year, day int) (*Solution, error) {
+	solution, ok := registry[solutionKey{year, day}]
+	if !ok {
+		return nil, fmt.Errorf("no solution registered for %d day %02d", year, day)
+	}
+	return solution, nil
+}
+
+// Solutions returns all registered solutions ordered by year and day.
+func Solutions() []*Solution {
+	result := make([]*Solution, 0, len(registry))
+	for _, solution := range registry {
+		result = append(result, solution)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Year != result[j].Year {
+			return result[i].Year < result[j].Year
+		}
+		return result[i].Day < result[j].Day
+	})
+	return result
+}
+
+// SolutionsForYear returns the registered solutions of a single year ordered by day.
+func SolutionsForYear(year int) []*Solution {
+	result := make([]*Solution, 0)
+	for _, solution := range Solutions() {
+		if solution.Year == year {
+			result = append(result, solution)
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionEnvVar holds a raw session token. It takes precedence over the
+// cookie file.
+const SessionEnvVar = "AOC_SESSION_COOKIE"
+
+const sessionCookieName = "session"
+
+// readSessionCookie finds the session token for host. It is read from the
+// SessionEnvVar environment variable if set, otherwise from the file at
+// cookieFilePath, which either holds just the token or is a Netscape
+// cookies.txt export.
+func readSessionCookie(cookieFilePath string, host string) (*http.Cookie, error) {
+	token, err := readSessionToken(cookieFilePath, host)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Cookie{Name: sessionCookieName, Value: token}, nil
+}
+
+func readSessionToken(cookieFilePath string, host string) (string, error) {
+	if token := os.Getenv(SessionEnvVar); token != "" {
+		token, err := validateSessionToken(token)
+		if err != nil {
+			return "", fmt.Errorf("$%s: %w", SessionEnvVar, err)
+		}
+		return token, nil
+	}
+
+	content, err := os.ReadFile(cookieFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("no session: $%s is not set and cookie file %s does not exist", SessionEnvVar, cookieFilePath)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var token string
+	if isNetscapeCookieFile(string(content)) {
+		token, err = parseNetscapeSession(string(content), host, time.Now())
+	} else {
+		token, err = validateSessionToken(string(content))
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", cookieFilePath, err)
+	}
+
+	return token, nil
+}
+
+// isNetscapeCookieFile tells a cookies.txt export apart from a file holding
+// just the token: the export has tab separated rows.
+func isNetscapeCookieFile(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Count(line, "\t") >= 6 {
+			return true
+		}
+	}
+	return strings.HasPrefix(strings.TrimSpace(content), "# Netscape HTTP Cookie File")
+}
+
+// validateSessionToken checks a raw token, as found in the environment
+// variable or a single-line token file. A "session=" prefix is allowed.
+func validateSessionToken(token string) (string, error) {
+	token = strings.TrimSpace(token)
+	token = strings.TrimPrefix(token, sessionCookieName+"=")
+	if token == "" {
+		return "", errors.New("session token is empty")
+	}
+	if strings.ContainsAny(token, " \t\n") {
+		return "", errors.New("session token must be a single line without spaces")
+	}
+	for _, r := range token {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return "", fmt.Errorf("session token is not hexadecimal, got %q", r)
+		}
+	}
+	return token, nil
+}
+
+// parseNetscapeSession returns the session cookie for host from a Netscape
+// cookies.txt export, skipping comments, expired rows and other domains.
+func parseNetscapeSession(content string, host string, now time.Time) (string, error) {
+	var expired, otherDomain int
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		// Browsers mark HttpOnly cookies with a prefix that looks like a
+		// comment, every other '#' line really is one.
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// domain, include subdomains, path, secure, expiry, name, value
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return "", fmt.Errorf("line %d: expected 7 tab separated columns, got %d", i+1, len(fields))
+		}
+		if fields[5] != sessionCookieName {
+			continue
+		}
+
+		if !cookieDomainMatches(fields[0], host) {
+			otherDomain++
+			continue
+		}
+
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("line %d: invalid expiry %q", i+1, fields[4])
+		}
+		// Zero marks a session cookie, which never expires on disk.
+		if expiry != 0 && time.Unix(expiry, 0).Before(now) {
+			expired++
+			continue
+		}
+
+		token, err := validateSessionToken(fields[6])
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", i+1, err)
+		}
+		return token, nil
+	}
+
+	return "", fmt.Errorf("no valid session cookie for %s (%d expired, %d for other domains)", host, expired, otherDomain)
+}
+
+func cookieDomainMatches(domain string, host string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(domain), ".")
+	host = strings.ToLower(host)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
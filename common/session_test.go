@@ -0,0 +1,66 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadSessionToken(t *testing.T) {
+	const stale = "# Netscape HTTP Cookie File\n" +
+		"# This is a generated file! Do not edit.\n" +
+		"\n" +
+		".example.com\tTRUE\t/\tTRUE\t0\tsession\tbad0\n" +
+		".adventofcode.com\tTRUE\t/\tTRUE\t1000\tsession\tdead\n" +
+		".adventofcode.com\tTRUE\t/\tTRUE\t1700000000\t_ga\tGA1.2\n"
+	const netscape = stale + "#HttpOnly_.adventofcode.com\tTRUE\t/\tTRUE\t0\tsession\tc0ffee\n"
+
+	tests := []struct {
+		name    string
+		env     string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "netscape", content: netscape, want: "c0ffee"},
+		{name: "token-file", content: "c0ffee\n", want: "c0ffee"},
+		{name: "token-file-with-name", content: "session=c0ffee", want: "c0ffee"},
+		{name: "env-wins", env: "beef", content: netscape, want: "beef"},
+		{name: "env-invalid", env: "not a token", content: netscape, wantErr: true},
+		{name: "token-not-hex", content: "xyz", wantErr: true},
+		{name: "only-expired-or-other-domains", content: stale, wantErr: true},
+		{name: "missing-file", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SessionEnvVar, tt.env)
+			path := filepath.Join(t.TempDir(), "cookies.txt")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := readSessionToken(path, "adventofcode.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSessionToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readSessionToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNetscapeSessionExpiry(t *testing.T) {
+	content := ".adventofcode.com\tTRUE\t/\tTRUE\t2000\tsession\tc0ffee\n"
+
+	if _, err := parseNetscapeSession(content, "adventofcode.com", time.Unix(3000, 0)); err == nil {
+		t.Error("expected an error for an expired cookie")
+	}
+	if got, err := parseNetscapeSession(content, "adventofcode.com", time.Unix(1000, 0)); err != nil || got != "c0ffee" {
+		t.Errorf("parseNetscapeSession() = %q, %v, want %q", got, err, "c0ffee")
+	}
+}
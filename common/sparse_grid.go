@@ -0,0 +1,220 @@
+package common
+
+import (
+	"sort"
+	"strings"
+)
+
+// SparseGrid is a grid without edges, like the ones cellular automata and
+// robot trails grow on. Only cells set to something other than the
+// background are stored, and the bounding box of them is kept as they are
+// set, so rendering crops to the occupied area.
+type SparseGrid[T comparable] struct {
+	// Background is the value of every cell that was never set. Changing it
+	// leaves the cells that were set as they are.
+	Background T
+
+	cells map[Point]T
+	// min and max are the corners of the bounding box of cells, stale once
+	// a cell on its edge was cleared.
+	min, max Point
+	stale    bool
+}
+
+// NewSparseGrid returns an empty grid whose cells are all background.
+func NewSparseGrid[T comparable](background T) *SparseGrid[T] {
+	return &SparseGrid[T]{Background: background, cells: make(map[Point]T)}
+}
+
+// Sparse returns a sparse copy of g, leaving out the cells holding
+// background.
+func (g *Grid[T]) Sparse(background T) *SparseGrid[T] {
+	s := NewSparseGrid(background)
+	for i, cell := range g.cells {
+		s.Set(g.point(i), cell)
+	}
+	return s
+}
+
+// Dense returns a grid of the bounding box of s, and the point of s its
+// row and column 0 are at. Cells of s outside the box are background. An
+// empty grid has no box and gives an empty grid at the origin.
+func (s *SparseGrid[T]) Dense() (*Grid[T], Point) {
+	min, max, ok := s.Bounds()
+	if !ok {
+		return NewGrid[T](0, 0), Point{}
+	}
+	g := NewGrid[T](max.Row-min.Row+1, max.Col-min.Col+1)
+	for i := range g.cells {
+		g.cells[i] = s.Background
+	}
+	for p, v := range s.cells {
+		g.Set(p.Sub(min), v)
+	}
+	return g, min
+}
+
+// Len returns the number of cells that are not background.
+func (s *SparseGrid[T]) Len() int {
+	return len(s.cells)
+}
+
+// At returns the cell at p, the background when it was never set.
+func (s *SparseGrid[T]) At(p Point) T {
+	if v, ok := s.cells[p]; ok {
+		return v
+	}
+	return s.Background
+}
+
+// Set changes the cell at p. Setting it to the background clears it.
+func (s *SparseGrid[T]) Set(p Point, v T) {
+	if v == s.Background {
+		s.clear(p)
+		return
+	}
+
+	if len(s.cells) == 0 {
+		s.min, s.max, s.stale = p, p, false
+	} else {
+		s.grow(p)
+	}
+	s.cells[p] = v
+}
+
+func (s *SparseGrid[T]) clear(p Point) {
+	if _, ok := s.cells[p]; !ok {
+		return
+	}
+	delete(s.cells, p)
+	if p.Row == s.min.Row || p.Row == s.max.Row || p.Col == s.min.Col || p.Col == s.max.Col {
+		s.stale = true
+	}
+}
+
+func (s *SparseGrid[T]) grow(p Point) {
+	if p.Row < s.min.Row {
+		s.min.Row = p.Row
+	}
+	if p.Row > s.max.Row {
+		s.max.Row = p.Row
+	}
+	if p.Col < s.min.Col {
+		s.min.Col = p.Col
+	}
+	if p.Col > s.max.Col {
+		s.max.Col = p.Col
+	}
+}
+
+// Bounds returns the top left and bottom right corners of the smallest box
+// holding every cell that is not background, false when there are none.
+func (s *SparseGrid[T]) Bounds() (min, max Point, ok bool) {
+	if s.stale {
+		s.stale = false
+		first := true
+		for p := range s.cells {
+			if first {
+				s.min, s.max = p, p
+				first = false
+			} else {
+				s.grow(p)
+			}
+		}
+	}
+	return s.min, s.max, len(s.cells) > 0
+}
+
+// InBounds reports whether p is in the bounding box of the grid.
+func (s *SparseGrid[T]) InBounds(p Point) bool {
+	min, max, ok := s.Bounds()
+	return ok && p.Row >= min.Row && p.Row <= max.Row && p.Col >= min.Col && p.Col <= max.Col
+}
+
+// Neighbors4 returns the orthogonal neighbors of p, clockwise from the one
+// above. The grid has no edges, there are always four.
+func (s *SparseGrid[T]) Neighbors4(p Point) []Point {
+	return s.neighbors(p, neighborOffsets4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p, clockwise
+// from the one above. There are always eight.
+func (s *SparseGrid[T]) Neighbors8(p Point) []Point {
+	return s.neighbors(p, neighborOffsets8)
+}
+
+func (s *SparseGrid[T]) neighbors(p Point, offsets []Point) []Point {
+	neighbors := make([]Point, len(offsets))
+	for i, o := range offsets {
+		neighbors[i] = p.Add(o)
+	}
+	return neighbors
+}
+
+// Find returns the first cell holding v, row by row. The background is
+// everywhere and never found.
+func (s *SparseGrid[T]) Find(v T) (Point, bool) {
+	if points := s.FindAll(v); len(points) > 0 {
+		return points[0], true
+	}
+	return Point{}, false
+}
+
+// FindAll returns every cell holding v, row by row.
+func (s *SparseGrid[T]) FindAll(v T) []Point {
+	var points []Point
+	for p, cell := range s.cells {
+		if cell == v {
+			points = append(points, p)
+		}
+	}
+	sortPoints(points)
+	return points
+}
+
+// Points returns every cell that is not background, row by row.
+func (s *SparseGrid[T]) Points() []Point {
+	points := make([]Point, 0, len(s.cells))
+	for p := range s.cells {
+		points = append(points, p)
+	}
+	sortPoints(points)
+	return points
+}
+
+// Clone returns a grid with a copy of the cells of s.
+func (s *SparseGrid[T]) Clone() *SparseGrid[T] {
+	c := *s
+	c.cells = make(map[Point]T, len(s.cells))
+	for p, v := range s.cells {
+		c.cells[p] = v
+	}
+	return &c
+}
+
+// String renders the bounding box of the grid a line per row, like
+// Grid.String.
+func (s *SparseGrid[T]) String() string {
+	min, max, ok := s.Bounds()
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	for r := min.Row; r <= max.Row; r++ {
+		for c := min.Col; c <= max.Col; c++ {
+			writeCell(&sb, s.At(Point{r, c}))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// sortPoints sorts points row by row.
+func sortPoints(points []Point) {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Row != points[j].Row {
+			return points[i].Row < points[j].Row
+		}
+		return points[i].Col < points[j].Col
+	})
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSparseGrid(t *testing.T) {
+	grid := NewSparseGrid('.')
+	if _, _, ok := grid.Bounds(); ok || grid.String() != "" {
+		t.Fatalf("empty grid has bounds or renders %q", grid.String())
+	}
+
+	grid.Set(Point{-1, 5}, '#')
+	grid.Set(Point{2, 3}, '#')
+	grid.Set(Point{0, 4}, 'S')
+	grid.Set(Point{9, 9}, '.') // Background, not stored.
+
+	if grid.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", grid.Len())
+	}
+	if min, max, _ := grid.Bounds(); min != (Point{-1, 3}) || max != (Point{2, 5}) {
+		t.Errorf("Bounds() = %v, %v, want {-1 3}, {2 5}", min, max)
+	}
+	if got := grid.At(Point{100, -100}); got != '.' {
+		t.Errorf("At(far away) = %q, want the background", got)
+	}
+	if got, want := grid.String(), "..#\n.S.\n...\n#..\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := grid.FindAll('#'), []Point{{-1, 5}, {2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll('#') = %v, want %v", got, want)
+	}
+	if got, want := grid.Neighbors4(Point{-1, 5}), []Point{{-2, 5}, {-1, 6}, {0, 5}, {-1, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Neighbors4() = %v, want %v", got, want)
+	}
+
+	// Clearing a cell on the edge shrinks the box, in a clone only.
+	clone := grid.Clone()
+	clone.Set(Point{-1, 5}, '.')
+	if min, max, _ := clone.Bounds(); min != (Point{0, 3}) || max != (Point{2, 4}) {
+		t.Errorf("Bounds() after clearing = %v, %v, want {0 3}, {2 4}", min, max)
+	}
+	if grid.At(Point{-1, 5}) != '#' || grid.Len() != 3 {
+		t.Error("clearing a cell of the clone cleared it in the grid")
+	}
+}
+
+func TestSparseGridDense(t *testing.T) {
+	dense := MustParseGrid("..#\n.S.\n")
+	sparse := dense.Sparse('.')
+	if got, want := sparse.Points(), []Point{{0, 2}, {1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Points() = %v, want %v", got, want)
+	}
+
+	sparse.Set(Point{-2, -1}, '#')
+	back, origin := sparse.Dense()
+	if origin != (Point{-2, -1}) {
+		t.Errorf("Dense() origin = %v, want {-2 -1}", origin)
+	}
+	if got, want := back.String(), "#...\n....\n...#\n..S.\n"; got != want {
+		t.Errorf("Dense() = %q, want %q", got, want)
+	}
+	if got := back.At(Point{1, 1}.Sub(origin)); got != 'S' {
+		t.Errorf("Dense() has %q at S, want 'S'", got)
+	}
+}
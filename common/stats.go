@@ -0,0 +1,177 @@
+package common
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// RunStats are measured around a single solve.
+type RunStats struct {
+	// Parse is the time spent in code wrapped by TimeParsing, zero when the
+	// solver does not report it.
+	Parse time.Duration `json:"parse_ns"`
+	Solve time.Duration `json:"solve_ns"`
+	Total time.Duration `json:"total_ns"`
+
+	Allocs     uint64 `json:"allocs"`
+	AllocBytes uint64 `json:"alloc_bytes"`
+	PeakHeap   uint64 `json:"peak_heap_bytes"`
+}
+
+// RunResult is the answer of one part along with how it was computed.
+type RunResult struct {
+	Year int `json:"year"`
+	Day  int `json:"day"`
+	Part int `json:"part"`
+	// Input names the file the input was read from, empty for the embedded
+	// input.
+	Input string `json:"input,omitempty"`
+	// Params are the parameters set on the command line.
+	Params map[string]string `json:"params,omitempty"`
+	Result string            `json:"result"`
+	Kind   AnswerKind        `json:"kind"`
+	// Diagnostics are the diagnostic lines of the answer.
+	Diagnostics []string `json:"diagnostics,omitempty"`
+	RunStats
+}
+
+// Footer renders the stats as the lines printed under a result.
+func (rs RunStats) Footer() string {
+	parse := "n/a"
+	if rs.Parse > 0 {
+		parse = rs.Parse.Round(time.Microsecond).String()
+	}
+	return fmt.Sprintf("Time: parse %s, solve %v, total %v\nMemory: %d allocs, %s allocated, %s peak heap",
+		parse,
+		rs.Solve.Round(time.Microsecond),
+		rs.Total.Round(time.Microsecond),
+		rs.Allocs,
+		FormatBytes(rs.AllocBytes),
+		FormatBytes(rs.PeakHeap),
+	)
+}
+
+// parseTimer accumulates the time reported through TimeParsing during a solve.
+var parseTimer struct {
+	mu    sync.Mutex
+	total time.Duration
+}
+
+// TimeParsing lets a solver report how long parsing its input takes, so the
+// runner can tell parsing and solving apart. Call it as
+//
+//	defer common.TimeParsing()()
+//
+// at the top of the parse function.
+func TimeParsing() func() {
+	start := time.Now()
+	return func() {
+		parseTimer.mu.Lock()
+		defer parseTimer.mu.Unlock()
+		parseTimer.total += time.Since(start)
+	}
+}
+
+func resetParseTimer() {
+	parseTimer.mu.Lock()
+	defer parseTimer.mu.Unlock()
+	parseTimer.total = 0
+}
+
+func parseTime() time.Duration {
+	parseTimer.mu.Lock()
+	defer parseTimer.mu.Unlock()
+	return parseTimer.total
+}
+
+// heapSampleInterval is how often the heap is sampled for its peak.
+const heapSampleInterval = 10 * time.Millisecond
+
+// heapObjectsMetric is the memory held by heap objects, what
+// runtime.MemStats calls HeapAlloc. Reading it through runtime/metrics does
+// not stop the world, unlike runtime.ReadMemStats.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// heapAlloc returns the memory held by heap objects.
+func heapAlloc(sample []metrics.Sample) uint64 {
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// measure runs fn and returns its result with the time and memory it took.
+func measure[T any](fn func() T) (T, RunStats) {
+	// Start from a clean heap so the peak belongs to this solve.
+	runtime.GC()
+	resetParseTimer()
+
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	peak := before.HeapAlloc
+
+	// Sample the heap in the background, a single read at the end would
+	// miss everything collected during the solve.
+	done := make(chan struct{})
+	sampled := make(chan uint64)
+	go func() {
+		peak := uint64(0)
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+		sample := []metrics.Sample{{Name: heapObjectsMetric}}
+		for {
+			select {
+			case <-done:
+				sampled <- peak
+				return
+			case <-ticker.C:
+				if heap := heapAlloc(sample); heap > peak {
+					peak = heap
+				}
+			}
+		}
+	}()
+
+	start := time.Now()
+	result := fn()
+	total := time.Since(start)
+
+	close(done)
+	if p := <-sampled; p > peak {
+		peak = p
+	}
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	if after.HeapAlloc > peak {
+		peak = after.HeapAlloc
+	}
+
+	parse := parseTime()
+	return result, RunStats{
+		Parse:      parse,
+		Solve:      total - parse,
+		Total:      total,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		PeakHeap:   peak,
+	}
+}
+
+// FormatBytes renders a byte count with a binary unit.
+func FormatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestMeasure(t *testing.T) {
+	result, stats := measure(func() string {
+		stop := TimeParsing()
+		time.Sleep(5 * time.Millisecond)
+		stop()
+
+		buf := make([]byte, 1<<20)
+		return string(buf[:1])
+	})
+
+	if result != "\x00" {
+		t.Errorf("measure() result = %q", result)
+	}
+	if stats.Parse < 5*time.Millisecond {
+		t.Errorf("Parse = %v, want at least 5ms", stats.Parse)
+	}
+	if stats.Total != stats.Parse+stats.Solve {
+		t.Errorf("Total = %v, want Parse + Solve = %v", stats.Total, stats.Parse+stats.Solve)
+	}
+	if stats.AllocBytes < 1<<20 {
+		t.Errorf("AllocBytes = %v, want at least 1 MiB", stats.AllocBytes)
+	}
+}
+
+func TestMeasurePeakHeap(t *testing.T) {
+	// The buffer is collected before the solve ends, only the sampler sees
+	// it.
+	_, stats := measure(func() int {
+		buf := make([]byte, 8<<20)
+		time.Sleep(5 * heapSampleInterval)
+		n := len(buf)
+		runtime.KeepAlive(buf)
+		buf = nil
+		runtime.GC()
+		return n
+	})
+	if stats.PeakHeap < 8<<20 {
+		t.Errorf("PeakHeap = %v, want at least 8 MiB", FormatBytes(stats.PeakHeap))
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KiB"},
+		{3 << 20, "3.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
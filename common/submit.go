@@ -0,0 +1,170 @@
+package common
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Verdict is the site's judgement of a submitted answer.
+type Verdict int
+
+const (
+	VerdictUnknown Verdict = iota
+	VerdictCorrect
+	VerdictTooHigh
+	VerdictTooLow
+	VerdictWrong
+	VerdictRateLimited
+	VerdictAlreadySolved
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictCorrect:
+		return "correct"
+	case VerdictTooHigh:
+		return "too high"
+	case VerdictTooLow:
+		return "too low"
+	case VerdictWrong:
+		return "wrong"
+	case VerdictRateLimited:
+		return "rate limited"
+	case VerdictAlreadySolved:
+		return "already solved"
+	default:
+		return "unknown"
+	}
+}
+
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for candidate := VerdictUnknown; candidate <= VerdictAlreadySolved; candidate++ {
+		if candidate.String() == string(text) {
+			*v = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// SubmitResult is the parsed response to a submitted answer.
+type SubmitResult struct {
+	Verdict Verdict
+	// Wait is how long the site asks us to wait before the next submission,
+	// zero if it did not say.
+	Wait time.Duration
+	// Message is the text of the response article, for anything the
+	// verdict does not capture.
+	Message string
+}
+
+type AnswerSubmitter interface {
+	Submit(part int, answer string) (*SubmitResult, error)
+	MustSubmit(part int, answer string) *SubmitResult
+}
+
+type baseAnswerSubmitterImpl struct {
+	flags  *InputGetterFlags
+	client *siteClient
+}
+
+func NewAnswerSubmitter(flags *InputGetterFlags) (AnswerSubmitter, error) {
+	client, err := newSiteClient(flags)
+	if err != nil {
+		return nil, err
+	}
+	return &baseAnswerSubmitterImpl{
+		flags:  flags,
+		client: client,
+	}, nil
+}
+
+func (as *baseAnswerSubmitterImpl) MustSubmit(part int, answer string) *SubmitResult {
+	result, err := as.Submit(part, answer)
+	CheckErr(err, "Failed to submit answer")
+	return result
+}
+
+func (as *baseAnswerSubmitterImpl) Submit(part int, answer string) (*SubmitResult, error) {
+	if part != 1 && part != 2 {
+		return nil, fmt.Errorf("invalid part %d", part)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return nil, fmt.Errorf("refusing to submit an empty answer")
+	}
+
+	form := url.Values{}
+	form.Set("level", Itoa(part))
+	form.Set("answer", answer)
+
+	page, err := as.client.postForm(as.flags.AnswerUrl(), form)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSubmitResponse(page), nil
+}
+
+var (
+	articleRegexp  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp      = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp    = regexp.MustCompile(`\s+`)
+	waitLeftRegexp = regexp.MustCompile(`You have (?:(\d+)m\s*)?(\d+)s left to wait`)
+	waitRegexp     = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseSubmitResponse turns the HTML page returned for a submission into a
+// typed result.
+func ParseSubmitResponse(page string) *SubmitResult {
+	message := page
+	if match := articleRegexp.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagRegexp.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spaceRegexp.ReplaceAllString(message, " "))
+
+	result := &SubmitResult{Message: message}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(message, "your answer is too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(message, "your answer is too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Verdict = VerdictWrong
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Verdict = VerdictRateLimited
+	case strings.Contains(message, "Did you already complete it"):
+		result.Verdict = VerdictAlreadySolved
+	default:
+		result.Verdict = VerdictUnknown
+	}
+
+	if match := waitLeftRegexp.FindStringSubmatch(message); match != nil {
+		minutes := 0
+		if match[1] != "" {
+			minutes = MustAtoi(match[1])
+		}
+		seconds := MustAtoi(match[2])
+		result.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := waitRegexp.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes = MustAtoi(match[1])
+		}
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return result
+}
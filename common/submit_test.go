@@ -0,0 +1,119 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestCookies(t *testing.T) string {
+	t.Helper()
+	t.Setenv(SessionEnvVar, "")
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	content := "127.0.0.1\tFALSE\t/\tFALSE\t0\tsession\t53e55105\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSubmit(t *testing.T) {
+	const page = `<html><body><main>
+<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>.  Please wait one minute before trying again. <a href="/2024/day/1">[Return to Day 1]</a></p></article>
+</main></body></html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/1/answer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "53e55105" {
+			t.Errorf("missing session cookie: %v", err)
+		}
+		if got := r.FormValue("level"); got != "2" {
+			t.Errorf("level = %q, want %q", got, "2")
+		}
+		if got := r.FormValue("answer"); got != "1234" {
+			t.Errorf("answer = %q, want %q", got, "1234")
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	submitter, err := NewAnswerSubmitter(&InputGetterFlags{
+		Year:           2024,
+		Day:            1,
+		CookieFilePath: writeTestCookies(t),
+		BaseUrl:        server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := submitter.Submit(2, " 1234\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verdict != VerdictTooHigh {
+		t.Errorf("verdict = %v, want %v", result.Verdict, VerdictTooHigh)
+	}
+	if result.Wait != time.Minute {
+		t.Errorf("wait = %v, want %v", result.Wait, time.Minute)
+	}
+}
+
+func TestParseSubmitResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{
+			name:    "correct",
+			page:    `<article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the Chief Historian.</p></article>`,
+			verdict: VerdictCorrect,
+		},
+		{
+			name:    "too-low",
+			page:    `<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`,
+			verdict: VerdictTooLow,
+			wait:    5 * time.Minute,
+		},
+		{
+			name:    "wrong",
+			page:    `<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article>`,
+			verdict: VerdictWrong,
+		},
+		{
+			name:    "rate-limited",
+			page:    `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.</p></article>`,
+			verdict: VerdictRateLimited,
+			wait:    65 * time.Second,
+		},
+		{
+			name:    "already-solved",
+			page:    `<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`,
+			verdict: VerdictAlreadySolved,
+		},
+		{
+			name:    "unknown",
+			page:    `<html>Something else</html>`,
+			verdict: VerdictUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseSubmitResponse(tt.page)
+			if result.Verdict != tt.verdict {
+				t.Errorf("verdict = %v, want %v (message %q)", result.Verdict, tt.verdict, result.Message)
+			}
+			if result.Wait != tt.wait {
+				t.Errorf("wait = %v, want %v", result.Wait, tt.wait)
+			}
+		})
+	}
+}
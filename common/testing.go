@@ -1,8 +1,12 @@
 package common
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 )
 
 type Test struct {
@@ -10,20 +14,140 @@ type Test struct {
 	Input string
 	Part  int
 	Want  string
+	// Params overrides parameters of a ParameterizedSolver, typically to
+	// match the size of the example.
+	Params map[string]string
+	// Timeout is how long the test may take, DefaultTestTimeout when zero.
+	Timeout time.Duration
 }
 
+// DefaultTestTimeout bounds tests without a Timeout of their own. Examples
+// are small, a solver taking longer is most likely stuck.
+const DefaultTestTimeout = 10 * time.Second
+
+// RunTests runs every test as a subtest. The log of the solver is silenced,
+// examples are checked for their answer.
 func RunTests(ps ProblemSolver, t *testing.T, tests []Test) {
+	defer SetLogger(NewLogger(io.Discard, LevelOff))()
+
 	for _, tt := range tests {
 		name := fmt.Sprintf("%s-part%d", tt.Name, tt.Part)
 		t.Run(name, func(t *testing.T) {
-			fn := ps.SolvePart1
-			if tt.Part == 2 {
-				fn = ps.SolvePart2
+			solver, err := ConfigureSolver(ps, tt.Params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			timeout := tt.Timeout
+			if timeout == 0 {
+				timeout = DefaultTestTimeout
 			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
 
-			if got := fn(tt.Input); got != tt.Want {
+			got, err := SolveContext(ctx, solver, tt.Part, tt.Input)
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				t.Fatalf("part%d() did not finish within %v%s", tt.Part, timeout, cancellationHint(solver))
+			case err != nil:
+				t.Fatalf("part%d() failed: %v", tt.Part, err)
+			}
+			for _, diagnostic := range got.Diagnostics {
+				t.Log(diagnostic)
+			}
+			if !got.Matches(tt.Want) {
 				t.Errorf("part%d() = %v, want %v", tt.Part, got, tt.Want)
 			}
 		})
 	}
 }
+
+// DefaultVerifyTimeout bounds each part checked by RunVerifyTests, a part
+// taking longer fails. It is generous, the slowest checked parts take
+// about half a minute.
+const DefaultVerifyTimeout = 2 * time.Minute
+
+// RunVerifyTests checks every registered solution against the answers file
+// of its day below root. Parts without a recorded answer are skipped, as
+// are the slow ones, subtest names like y2024d16-part2 that take minutes and
+// are only checked by aoc verify.
+func RunVerifyTests(t *testing.T, root string, slow ...string) {
+	if testing.Short() {
+		t.Skip("solving real inputs is skipped in short mode")
+	}
+
+	isSlow := make(map[string]bool)
+	for _, name := range slow {
+		isSlow[name] = true
+	}
+
+	for _, solution := range Solutions() {
+		answers, err := ReadAnswers(AnswersPath(root, solution.Year, solution.Day))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for part := 1; part <= 2; part++ {
+			name := fmt.Sprintf("y%dd%02d-part%d", solution.Year, solution.Day, part)
+			t.Run(name, func(t *testing.T) {
+				if isSlow[name] {
+					t.Skip("slow, check it with aoc verify")
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), DefaultVerifyTimeout)
+				defer cancel()
+
+				result := Verify(ctx, solution, answers, part, false)
+				switch {
+				case result.Status == VerifyMissing:
+					t.Skip("no recorded answer")
+				case errors.Is(result.Err, context.DeadlineExceeded):
+					t.Fatalf("part%d() did not finish within %v", part, DefaultVerifyTimeout)
+				case result.Err != nil:
+					t.Errorf("part%d() failed: %v", part, result.Err)
+				case result.Status == VerifyFail:
+					t.Errorf("part%d() = %v, want %v", part, result.Got, result.Want)
+				}
+			})
+		}
+	}
+}
+
+// Benchmark is a solve measured by RunBenchmarks, usually of the real input.
+type Benchmark struct {
+	Name  string
+	Input string
+	Part  int
+	// Params overrides parameters of a ParameterizedSolver.
+	Params map[string]string
+}
+
+// RunBenchmarks measures every benchmark as a sub-benchmark named like the
+// subtests of RunTests, reporting allocations. The log of the solver is
+// silenced.
+func RunBenchmarks(ps ProblemSolver, b *testing.B, benchmarks []Benchmark) {
+	defer SetLogger(NewLogger(io.Discard, LevelOff))()
+
+	for _, bm := range benchmarks {
+		name := fmt.Sprintf("%s-part%d", bm.Name, bm.Part)
+		b.Run(name, func(b *testing.B) {
+			if bm.Input == "" {
+				b.Skip(ErrNoInput)
+			}
+			solver, err := ConfigureSolver(ps, bm.Params)
+			if err != nil {
+				b.Fatal(err)
+			}
+			solve := solveFunc(solver, bm.Part)
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := solve(ctx, bm.Input); err != nil {
+					b.Fatalf("part%d() failed: %v", bm.Part, err)
+				}
+			}
+		})
+	}
+}
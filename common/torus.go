@@ -0,0 +1,80 @@
+package common
+
+// Torus is a rows by cols space whose edges wrap around, stepping off one
+// side comes back in on the other. Every point is a place on it, Wrap gives
+// the one of them on the rows by cols grid.
+type Torus struct {
+	Rows, Cols int
+}
+
+// Wrap returns the point p is at on the rows by cols grid.
+func (t Torus) Wrap(p Point) Point {
+	return Point{Mod(p.Row, t.Rows), Mod(p.Col, t.Cols)}
+}
+
+// Step returns where p ends up after n steps of v, in one jump.
+func (t Torus) Step(p, v Point, n int) Point {
+	// Wrapping v and n first keeps n*v from overflowing for large n.
+	v = t.Wrap(v)
+	return t.Wrap(p.Add(Point{v.Row * Mod(n, t.Rows), v.Col * Mod(n, t.Cols)}))
+}
+
+// Neighbors4 returns the orthogonal neighbors of p, clockwise from the one
+// above, wrapped around the edges.
+func (t Torus) Neighbors4(p Point) []Point {
+	return t.neighbors(p, neighborOffsets4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p, clockwise
+// from the one above, wrapped around the edges.
+func (t Torus) Neighbors8(p Point) []Point {
+	return t.neighbors(p, neighborOffsets8)
+}
+
+func (t Torus) neighbors(p Point, offsets []Point) []Point {
+	neighbors := make([]Point, len(offsets))
+	for i, o := range offsets {
+		neighbors[i] = t.Wrap(p.Add(o))
+	}
+	return neighbors
+}
+
+// Quadrants of a Torus, the way Quadrant numbers them.
+const (
+	TopLeft = iota
+	TopRight
+	BottomLeft
+	BottomRight
+)
+
+// Quadrant returns which quarter of the grid p is in, false when it is on
+// the middle row or column. Only odd sizes have a middle, even ones split
+// evenly.
+func (t Torus) Quadrant(p Point) (int, bool) {
+	p = t.Wrap(p)
+	top, bottom := p.Row < t.Rows/2, p.Row >= (t.Rows+1)/2
+	left, right := p.Col < t.Cols/2, p.Col >= (t.Cols+1)/2
+	switch {
+	case top && left:
+		return TopLeft, true
+	case top && right:
+		return TopRight, true
+	case bottom && left:
+		return BottomLeft, true
+	case bottom && right:
+		return BottomRight, true
+	}
+	return 0, false
+}
+
+// CountQuadrants counts the points in each quadrant, indexed by TopLeft
+// and the others. Points on a middle line are not counted.
+func (t Torus) CountQuadrants(points []Point) [4]int {
+	var counts [4]int
+	for _, p := range points {
+		if q, ok := t.Quadrant(p); ok {
+			counts[q]++
+		}
+	}
+	return counts
+}
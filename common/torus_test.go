@@ -0,0 +1,60 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMod(t *testing.T) {
+	for _, tc := range []struct{ a, m, want int }{{7, 5, 2}, {-1, 5, 4}, {-10, 5, 0}, {0, 3, 0}} {
+		if got := Mod(tc.a, tc.m); got != tc.want {
+			t.Errorf("Mod(%d, %d) = %d, want %d", tc.a, tc.m, got, tc.want)
+		}
+	}
+}
+
+func TestTorus(t *testing.T) {
+	room := Torus{Rows: 7, Cols: 11}
+
+	if got, want := room.Wrap(Point{-1, 11}), (Point{6, 0}); got != want {
+		t.Errorf("Wrap() = %v, want %v", got, want)
+	}
+
+	// The robot of the 2024 day 14 example, p=2,4 v=2,-3.
+	p, v := Point{4, 2}, Point{-3, 2}
+	walked := p
+	for i := 0; i < 5; i++ {
+		walked = room.Step(walked, v, 1)
+	}
+	if got, want := room.Step(p, v, 5), (Point{3, 1}); got != want || walked != want {
+		t.Errorf("Step(5) = %v, five single steps = %v, want %v", got, walked, want)
+	}
+	if got := room.Step(p, v, 77*1_000_000_000_000); got != p {
+		t.Errorf("Step(a multiple of 77) = %v, want back at %v", got, p)
+	}
+	if got := room.Step(p, v, -5); room.Step(got, v, 5) != p {
+		t.Errorf("Step(-5) = %v, which does not step back to %v", got, p)
+	}
+
+	if got, want := room.Neighbors4(Point{0, 0}), []Point{{6, 0}, {0, 1}, {1, 0}, {0, 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Neighbors4(corner) = %v, want %v", got, want)
+	}
+}
+
+func TestTorusQuadrants(t *testing.T) {
+	odd := Torus{Rows: 3, Cols: 3}
+	points := []Point{{0, 0}, {0, 2}, {2, 0}, {2, 2}, {2, 2}, {1, 0}, {0, 1}, {1, 1}}
+	if got, want := odd.CountQuadrants(points), [4]int{1, 1, 1, 2}; got != want {
+		t.Errorf("CountQuadrants(3x3) = %v, want %v", got, want)
+	}
+
+	even := Torus{Rows: 2, Cols: 4}
+	for _, tc := range []struct {
+		p    Point
+		want int
+	}{{Point{0, 1}, TopLeft}, {Point{0, 2}, TopRight}, {Point{1, 1}, BottomLeft}, {Point{1, 3}, BottomRight}} {
+		if got, ok := even.Quadrant(tc.p); !ok || got != tc.want {
+			t.Errorf("Quadrant(%v) = %d, %v, want %d", tc.p, got, ok, tc.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ErrLocked is returned when asking for a puzzle before it unlocks.
+var ErrLocked = errors.New("puzzle is not unlocked yet")
+
+// unlockZone is the time zone puzzles unlock in: midnight EST, which is
+// UTC-5 all of December.
+var unlockZone = time.FixedZone("EST", -5*60*60)
+
+// unlockJitter is the most we wait on top of the unlock instant, so not every
+// client hits the site in the same millisecond.
+const unlockJitter = 2 * time.Second
+
+// UnlockTime returns the instant the puzzle of the given year and day
+// unlocks.
+func UnlockTime(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, unlockZone)
+}
+
+// CheckUnlocked returns an error wrapping ErrLocked if the puzzle is still
+// locked at now.
+func CheckUnlocked(year, day int, now time.Time) error {
+	unlock := UnlockTime(year, day)
+	if now.Before(unlock) {
+		return fmt.Errorf("%w: %d day %d unlocks in %v, at %v", ErrLocked, year, day,
+			unlock.Sub(now).Round(time.Second), unlock.Local().Format(time.RFC1123))
+	}
+	return nil
+}
+
+// WaitForUnlock blocks until the puzzle unlocks, printing a countdown, then
+// waits a small random jitter. It returns right away for unlocked puzzles.
+func WaitForUnlock(year, day int) {
+	unlock := UnlockTime(year, day)
+	if !time.Now().Before(unlock) {
+		return
+	}
+
+	fmt.Printf("\tWaiting for %d day %d to unlock at %v\n", year, day, unlock.Local().Format(time.RFC1123))
+	for remaining := time.Until(unlock); remaining > 0; remaining = time.Until(unlock) {
+		fmt.Printf("\r\tUnlocks in %-12v", remaining.Round(time.Second))
+		if remaining > time.Second {
+			remaining = time.Second
+		}
+		time.Sleep(remaining)
+	}
+	fmt.Println()
+
+	time.Sleep(time.Duration(rand.Int63n(int64(unlockJitter))))
+}
@@ -0,0 +1,22 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUnlockTime(t *testing.T) {
+	want := time.Date(2024, time.December, 17, 5, 0, 0, 0, time.UTC)
+	if got := UnlockTime(2024, 17); !got.Equal(want) {
+		t.Errorf("UnlockTime() = %v, want %v", got, want)
+	}
+
+	before := want.Add(-90 * time.Second)
+	if err := CheckUnlocked(2024, 17, before); !errors.Is(err, ErrLocked) {
+		t.Errorf("CheckUnlocked() at %v = %v, want %v", before, err, ErrLocked)
+	}
+	if err := CheckUnlocked(2024, 17, want); err != nil {
+		t.Errorf("CheckUnlocked() at %v = %v, want nil", want, err)
+	}
+}
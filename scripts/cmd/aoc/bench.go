@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func benchCmd(args []string) error {
+	positional, rest := splitPositional(args)
+
+	fs := flag.NewFlagSet("aoc bench", flag.ContinueOnError)
+	benchtime := fs.String("benchtime", "1s", "run each benchmark for this long, or Nx times")
+	timeout := fs.Duration("timeout", 30*time.Minute, "give up on a day after this long")
+	history := fs.String("history", common.InModuleRoot(common.DefaultHistoryPath), "append the results to this file, empty to disable")
+
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+
+	year, day, err := parseYearDay(append(positional, fs.Args()...))
+	if err != nil {
+		return err
+	}
+
+	var solutions []*common.Solution
+	switch {
+	case year == 0:
+		return errors.New("missing year")
+	case day != 0:
+		solution, err := common.Lookup(year, day)
+		if err != nil {
+			return err
+		}
+		solutions = []*common.Solution{solution}
+	default:
+		solutions = common.SolutionsForYear(year)
+		if len(solutions) == 0 {
+			return fmt.Errorf("no solutions registered for %d", year)
+		}
+	}
+
+	root, err := common.FindModuleRoot()
+	if err != nil {
+		return err
+	}
+
+	goArgs := []string{"test", "-run", "^$", "-bench", ".", "-benchmem",
+		"-benchtime", *benchtime, "-timeout", timeout.String()}
+	for _, solution := range solutions {
+		goArgs = append(goArgs, fmt.Sprintf("./%d/day%02d", solution.Year, solution.Day))
+	}
+
+	cmd := exec.Command("go", goArgs...)
+	cmd.Dir = root
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	results, parseErr := parseBenchOutput(stdout, os.Stderr)
+	waitErr := cmd.Wait()
+	if parseErr != nil {
+		return parseErr
+	}
+
+	// Days that failed are missing, the others are still worth a table.
+	printBenchTable(os.Stdout, results)
+	if *history != "" && len(results) > 0 {
+		if err := common.AppendHistory(*history, benchHistory(results)...); err != nil {
+			return fmt.Errorf("recording history: %w", err)
+		}
+	}
+	if waitErr != nil {
+		return fmt.Errorf("go test: %w", waitErr)
+	}
+	return nil
+}
+
+// benchResult is one line of go test -bench -benchmem output.
+type benchResult struct {
+	Year, Day, Part int
+	NsPerOp         float64
+	BytesPerOp      int64
+	AllocsPerOp     int64
+}
+
+// benchLineRegexp matches the lines of benchmarks run by RunBenchmarks, like
+//
+//	Benchmark_y2024d01/input-part1-8   1000   378998 ns/op   115248 B/op   1029 allocs/op
+var benchLineRegexp = regexp.MustCompile(`^Benchmark_y(\d{4})d(\d{2})/\S*-part(\d)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op(?:\s+(\d+) B/op)?(?:\s+(\d+) allocs/op)?`)
+
+// packageLineRegexp matches the line go test prints when a package is done.
+var packageLineRegexp = regexp.MustCompile(`^(ok|FAIL)\s`)
+
+// parseBenchOutput reads the benchmark results from go test output. Package
+// lines are copied to progress as they come, a year takes a while.
+func parseBenchOutput(r io.Reader, progress io.Writer) ([]benchResult, error) {
+	var results []benchResult
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if packageLineRegexp.MatchString(line) {
+			fmt.Fprintln(progress, line)
+			continue
+		}
+
+		m := benchLineRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		result := benchResult{
+			Year: common.MustAtoi(m[1]),
+			Day:  common.MustAtoi(m[2]),
+			Part: common.MustAtoi(m[3]),
+		}
+		result.NsPerOp, _ = strconv.ParseFloat(m[4], 64)
+		result.BytesPerOp, _ = strconv.ParseInt(m[5], 10, 64)
+		result.AllocsPerOp, _ = strconv.ParseInt(m[6], 10, 64)
+		results = append(results, result)
+	}
+	return results, scanner.Err()
+}
+
+// printBenchTable prints a row per part and the total time of the year.
+func printBenchTable(out io.Writer, results []benchResult) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tTIME/OP\tNS/OP\tMEMORY/OP\tALLOCS/OP\t")
+
+	var total float64
+	for _, r := range results {
+		total += r.NsPerOp
+		fmt.Fprintf(w, "%d\t%02d\t%d\t%v\t%.0f\t%s\t%d\t\n",
+			r.Year, r.Day, r.Part,
+			formatNs(r.NsPerOp), r.NsPerOp,
+			common.FormatBytes(uint64(r.BytesPerOp)), r.AllocsPerOp)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t%v\t%.0f\t\t\t\n", formatNs(total), total)
+	w.Flush()
+}
+
+// benchHistory turns results into history entries of the current commit.
+func benchHistory(results []benchResult) []common.HistoryEntry {
+	commit := common.CurrentCommit()
+	now := time.Now().UTC()
+	entries := make([]common.HistoryEntry, len(results))
+	for i, r := range results {
+		entries[i] = common.HistoryEntry{
+			Commit:   commit,
+			Time:     now,
+			Source:   common.HistorySourceBench,
+			Year:     r.Year,
+			Day:      r.Day,
+			Part:     r.Part,
+			Duration: time.Duration(r.NsPerOp),
+			Allocs:   uint64(r.AllocsPerOp),
+		}
+	}
+	return entries
+}
+
+func formatNs(ns float64) time.Duration {
+	return time.Duration(ns).Round(time.Microsecond)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBenchOutput(t *testing.T) {
+	const output = `goos: linux
+goarch: amd64
+pkg: github.com/jhh3/aoc/2024/day01
+Benchmark_y2024d01/input-part1-8         	    3042	    378998 ns/op	  115248 B/op	    1029 allocs/op
+Benchmark_y2024d01/input-part2           	       1	 212509581 ns/op	  235032 B/op	    2068 allocs/op
+PASS
+ok  	github.com/jhh3/aoc/2024/day01	2.506s
+`
+
+	var progress strings.Builder
+	results, err := parseBenchOutput(strings.NewReader(output), &progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []benchResult{
+		{Year: 2024, Day: 1, Part: 1, NsPerOp: 378998, BytesPerOp: 115248, AllocsPerOp: 1029},
+		{Year: 2024, Day: 1, Part: 2, NsPerOp: 212509581, BytesPerOp: 235032, AllocsPerOp: 2068},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %+v, want %+v", results, want)
+	}
+	if !strings.HasPrefix(progress.String(), "ok  \tgithub.com/jhh3/aoc/2024/day01") {
+		t.Errorf("progress = %q", progress.String())
+	}
+
+	var table strings.Builder
+	printBenchTable(&table, results)
+	// Columns are aligned with spaces, compare the cells of every line.
+	var got [][]string
+	for _, line := range strings.Split(strings.TrimRight(table.String(), "\n"), "\n") {
+		got = append(got, strings.Fields(line))
+	}
+	wantTable := [][]string{
+		{"YEAR", "DAY", "PART", "TIME/OP", "NS/OP", "MEMORY/OP", "ALLOCS/OP"},
+		{"2024", "01", "1", "379µs", "378998", "112.5", "KiB", "1029"},
+		{"2024", "01", "2", "212.51ms", "212509581", "229.5", "KiB", "2068"},
+		{"TOTAL", "212.889ms", "212888579"},
+	}
+	if !reflect.DeepEqual(got, wantTable) {
+		t.Errorf("printBenchTable() =\n%s\nwant rows %q", table.String(), wantTable)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func listCmd(args []string) error {
+	year, day, err := parseYearDay(args)
+	if err != nil {
+		return err
+	}
+	if day != 0 {
+		return fmt.Errorf("list takes only a year, got day %d", day)
+	}
+
+	solutions := common.Solutions()
+	if year != 0 {
+		solutions = common.SolutionsForYear(year)
+	}
+
+	for _, solution := range solutions {
+		fmt.Println(solution)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"run":  {usage: "run <year> [<day>] [-all] [-part N]", run: runCmd},
+	"list": {usage: "list [<year>]", run: listCmd},
+	"submit": {
+		usage: "submit <year> <day> [-part N] [-answer X] [-cookie path] [-ledger path]",
+		run:   submitCmd,
+	},
+	"bench": {
+		usage: "bench <year> [<day>] [-benchtime 1s]",
+		run:   benchCmd,
+	},
+	"perf": {
+		usage: "perf [<year>] [<day>] [-history path] [-window 5] [-threshold 0.2]",
+		run:   perfCmd,
+	},
+	"verify": {
+		usage: "verify [<year>] [<day>] [-root dir] [-record]",
+		run:   verifyCmd,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: aoc <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  aoc %s\n", commands[name].usage)
+	}
+}
+
+// splitPositional separates the leading positional arguments (year, day)
+// from the flags that follow them, so `aoc run 2024 17 -part 2` works even
+// though the flag package stops at the first non-flag argument.
+func splitPositional(args []string) ([]string, []string) {
+	i := 0
+	for i < len(args) && !strings.HasPrefix(args[i], "-") {
+		i++
+	}
+	return args[:i], args[i:]
+}
+
+// parseYearDay parses the optional year and day positional arguments. A
+// missing value is returned as 0.
+func parseYearDay(positional []string) (int, int, error) {
+	if len(positional) > 2 {
+		return 0, 0, fmt.Errorf("unexpected arguments: %v", positional[2:])
+	}
+
+	values := []int{0, 0}
+	for i, arg := range positional {
+		value, err := strconv.Atoi(strings.TrimPrefix(arg, "day"))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid year or day %q", arg)
+		}
+		values[i] = value
+	}
+
+	return values[0], values[1], nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func perfCmd(args []string) error {
+	positional, rest := splitPositional(args)
+
+	fs := flag.NewFlagSet("aoc perf", flag.ContinueOnError)
+	history := fs.String("history", common.InModuleRoot(common.DefaultHistoryPath), "history file written by run and bench")
+	window := fs.Int("window", 5, "number of previous runs the median is taken over")
+	threshold := fs.Float64("threshold", 0.2, "flag runs slower or allocating more than the median by this much, 0.2 for 20%")
+
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+	if *window < 1 {
+		return fmt.Errorf("invalid window %d, want at least 1", *window)
+	}
+
+	year, day, err := parseYearDay(append(positional, fs.Args()...))
+	if err != nil {
+		return err
+	}
+
+	entries, err := common.LoadHistory(*history)
+	if err != nil {
+		return err
+	}
+
+	var series []*common.HistorySeries
+	for _, s := range common.GroupHistory(entries) {
+		if (year == 0 || s.Year == year) && (day == 0 || s.Day == day) {
+			series = append(series, s)
+		}
+	}
+	if len(series) == 0 {
+		fmt.Printf("No history in %s yet, run or bench a day first\n", *history)
+		return nil
+	}
+
+	printPerfReport(os.Stdout, series, *window, *threshold)
+	return nil
+}
+
+// printPerfReport prints the trend of every series, then every run that
+// regressed.
+func printPerfReport(out io.Writer, series []*common.HistorySeries, window int, threshold float64) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tSOURCE\tRUNS\tLATEST\tMEDIAN\tCHANGE\tTREND\tCOMMIT")
+
+	var regressions []common.Regression
+	for _, s := range series {
+		last := len(s.Entries) - 1
+		latest := s.Entries[last]
+
+		med, change := "-", "-"
+		if m, ok := s.MedianBefore(last, window); ok {
+			med = formatNs(float64(m)).String()
+			if m > 0 {
+				change = fmt.Sprintf("%+.0f%%", (float64(latest.Duration)/float64(m)-1)*100)
+			}
+		}
+
+		fmt.Fprintf(w, "%d\t%02d\t%d\t%s\t%d\t%v\t%s\t%s\t%s\t%s\n",
+			s.Year, s.Day, s.Part, s.Source, len(s.Entries),
+			formatNs(float64(latest.Duration)), med, change,
+			sparkline(s.Entries, 2*window), latest.Commit)
+
+		regressions = append(regressions, s.Regressions(window, threshold)...)
+	}
+	w.Flush()
+
+	if len(regressions) == 0 {
+		fmt.Fprintf(out, "\nNo regressions over %.0f%% of the median of %d runs\n", threshold*100, window)
+		return
+	}
+
+	fmt.Fprintf(out, "\nRegressions over %.0f%% of the median of %d runs:\n", threshold*100, window)
+	for _, r := range regressions {
+		e := r.Entry
+		value, med := fmt.Sprintf("%.0f", r.Value), fmt.Sprintf("%.0f", r.Median)
+		if r.Metric == "time" {
+			value, med = formatNs(r.Value).String(), formatNs(r.Median).String()
+		}
+		fmt.Fprintf(out, "  %d day %02d part %d (%s) at %s, %s: %s %s, %+.0f%% over %s\n",
+			e.Year, e.Day, e.Part, e.Source, e.Commit, e.Time.Local().Format(time.DateTime),
+			r.Metric, value, r.Change()*100, med)
+	}
+}
+
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the durations of the last n entries, scaled between the
+// fastest and the slowest of them.
+func sparkline(entries []common.HistoryEntry, n int) string {
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+
+	lo, hi := entries[0].Duration, entries[0].Duration
+	for _, e := range entries {
+		if e.Duration < lo {
+			lo = e.Duration
+		}
+		if e.Duration > hi {
+			hi = e.Duration
+		}
+	}
+
+	var sb strings.Builder
+	for _, e := range entries {
+		i := 0
+		if hi > lo {
+			i = int(float64(e.Duration-lo) / float64(hi-lo) * float64(len(sparkRunes)-1))
+		}
+		sb.WriteRune(sparkRunes[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func runCmd(args []string) error {
+	positional, rest := splitPositional(args)
+
+	solverFlags := common.ProblemSolverFlags{}
+	fs := flag.NewFlagSet("aoc run", flag.ContinueOnError)
+	solverFlags.BindFlags(fs)
+	all := fs.Bool("all", false, "run every registered day of the year")
+
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+	if err := solverFlags.Validate(); err != nil {
+		return err
+	}
+
+	year, day, err := parseYearDay(append(positional, fs.Args()...))
+	if err != nil {
+		return err
+	}
+
+	var solutions []*common.Solution
+	switch {
+	case year == 0:
+		return errors.New("missing year")
+	case *all && day != 0:
+		return errors.New("-all and a day are mutually exclusive")
+	case *all:
+		solutions = common.SolutionsForYear(year)
+		if len(solutions) == 0 {
+			return fmt.Errorf("no solutions registered for %d", year)
+		}
+	case day == 0:
+		return errors.New("missing day, pass one or use -all")
+	default:
+		solution, err := common.Lookup(year, day)
+		if err != nil {
+			return err
+		}
+		solutions = append(solutions, solution)
+	}
+
+	if *all && solverFlags.InputPath != "" {
+		return errors.New("-all and -input are mutually exclusive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, solution := range solutions {
+		if _, err := common.NewProblemRunner(&solverFlags, solution).Run(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Code generated by skeleton. DO NOT EDIT.
+
+package main
+
+import (
+	_ "github.com/jhh3/aoc/2023/day01"
+	_ "github.com/jhh3/aoc/2023/day02"
+	_ "github.com/jhh3/aoc/2023/day03"
+	_ "github.com/jhh3/aoc/2023/day04"
+	_ "github.com/jhh3/aoc/2023/day05"
+	_ "github.com/jhh3/aoc/2023/day06"
+	_ "github.com/jhh3/aoc/2023/day07"
+	_ "github.com/jhh3/aoc/2023/day08"
+	_ "github.com/jhh3/aoc/2024/day01"
+	_ "github.com/jhh3/aoc/2024/day02"
+	_ "github.com/jhh3/aoc/2024/day03"
+	_ "github.com/jhh3/aoc/2024/day04"
+	_ "github.com/jhh3/aoc/2024/day05"
+	_ "github.com/jhh3/aoc/2024/day06"
+	_ "github.com/jhh3/aoc/2024/day07"
+	_ "github.com/jhh3/aoc/2024/day08"
+	_ "github.com/jhh3/aoc/2024/day09"
+	_ "github.com/jhh3/aoc/2024/day10"
+	_ "github.com/jhh3/aoc/2024/day11"
+	_ "github.com/jhh3/aoc/2024/day12"
+	_ "github.com/jhh3/aoc/2024/day13"
+	_ "github.com/jhh3/aoc/2024/day14"
+	_ "github.com/jhh3/aoc/2024/day15"
+	_ "github.com/jhh3/aoc/2024/day16"
+	_ "github.com/jhh3/aoc/2024/day17"
+	_ "github.com/jhh3/aoc/2024/day18"
+	_ "github.com/jhh3/aoc/2024/day19"
+	_ "github.com/jhh3/aoc/2024/day20"
+)
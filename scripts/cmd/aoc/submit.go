@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func submitCmd(args []string) error {
+	positional, rest := splitPositional(args)
+
+	clientFlags := common.InputGetterFlags{}
+	fs := flag.NewFlagSet("aoc submit", flag.ContinueOnError)
+	clientFlags.BindClientFlags(fs)
+	part := fs.Int("part", 1, "part 1 or 2")
+	answer := fs.String("answer", "", "answer to submit, computed by the solver when empty")
+	ledgerPath := fs.String("ledger", common.InModuleRoot(common.DefaultLedgerPath), "path to the answer ledger")
+	timeout := fs.Duration("timeout", 0, "give up computing the answer after this long, 0 for no limit")
+
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+
+	year, day, err := parseYearDay(append(positional, fs.Args()...))
+	if err != nil {
+		return err
+	}
+	if year == 0 || day == 0 {
+		return errors.New("submit needs a year and a day")
+	}
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part %d, want 1 or 2", *part)
+	}
+	clientFlags.Year = year
+	clientFlags.Day = day
+
+	if *answer == "" {
+		solution, err := common.Lookup(year, day)
+		if err != nil {
+			return err
+		}
+		input, err := solution.EmbeddedInput()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+
+		fmt.Println("Solving...")
+		computed, err := common.SolveContext(ctx, solution.Solver, *part, input)
+		if err != nil {
+			return err
+		}
+		*answer, err = computed.Submission()
+		if err != nil {
+			return fmt.Errorf("%w, read it and pass -answer:\n%s", err, computed)
+		}
+	}
+
+	root := common.InModuleRoot(".")
+	answers, err := common.ReadAnswers(common.AnswersPath(root, year, day))
+	if err != nil {
+		return err
+	}
+	if correct, ok := answers[*part]; ok {
+		return fmt.Errorf("not submitting: part already solved with %s", correct)
+	}
+
+	ledger, err := common.LoadLedger(*ledgerPath)
+	if err != nil {
+		return err
+	}
+	if err := ledger.Check(year, day, *part, *answer); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	fmt.Printf("Submitting %q for %d day %02d, part %d\n", *answer, year, day, *part)
+	submitter, err := common.NewAnswerSubmitter(&clientFlags)
+	if err != nil {
+		return err
+	}
+	result, err := submitter.Submit(*part, *answer)
+	if err != nil {
+		return err
+	}
+
+	ledger.Record(year, day, *part, *answer, result)
+	if err := ledger.Save(); err != nil {
+		return err
+	}
+	if result.Verdict == common.VerdictCorrect {
+		if err := common.RecordAnswer(root, year, day, *part, *answer); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Verdict:", result.Verdict)
+	if result.Wait > 0 {
+		fmt.Println("Wait:", result.Wait)
+	}
+	if result.Verdict == common.VerdictUnknown {
+		fmt.Println(result.Message)
+	}
+
+	return nil
+}
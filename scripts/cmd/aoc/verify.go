@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func verifyCmd(args []string) error {
+	positional, rest := splitPositional(args)
+
+	fs := flag.NewFlagSet("aoc verify", flag.ContinueOnError)
+	root := fs.String("root", common.InModuleRoot("."), "module root holding the YYYY/dayNN directories")
+	record := fs.Bool("record", false, "write the result of parts without a recorded answer to their answers file")
+	timeout := fs.Duration("timeout", 0, "fail a part that takes longer than this, 0 for no limit")
+
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+
+	year, day, err := parseYearDay(append(positional, fs.Args()...))
+	if err != nil {
+		return err
+	}
+
+	solutions := common.Solutions()
+	switch {
+	case day != 0:
+		solution, err := common.Lookup(year, day)
+		if err != nil {
+			return err
+		}
+		solutions = []*common.Solution{solution}
+	case year != 0:
+		solutions = common.SolutionsForYear(year)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tSTATUS\tTIME\tDETAILS")
+
+	failed := 0
+	for _, solution := range solutions {
+		path := common.AnswersPath(*root, solution.Year, solution.Day)
+		answers, err := common.ReadAnswers(path)
+		if err != nil {
+			return err
+		}
+
+		recorded := false
+		for part := 1; part <= 2; part++ {
+			partCtx, cancel := ctx, context.CancelFunc(func() {})
+			if *timeout > 0 {
+				partCtx, cancel = context.WithTimeout(ctx, *timeout)
+			}
+			result := common.Verify(partCtx, solution, answers, part, *record)
+			cancel()
+
+			details := ""
+			switch {
+			case result.Err != nil:
+				details = result.Err.Error()
+			case result.Status == common.VerifyFail:
+				details = fmt.Sprintf("got %s, want %s", result.Got, result.Want)
+			case result.Status == common.VerifyMissing && *record && result.Got != "":
+				answers[part] = result.Got
+				recorded = true
+				details = "recorded " + result.Got
+			}
+			if result.Status == common.VerifyFail {
+				failed++
+			}
+
+			duration := ""
+			if result.Duration > 0 {
+				duration = result.Duration.Round(time.Microsecond).String()
+			}
+			fmt.Fprintf(w, "%d\t%02d\t%d\t%s\t%s\t%s\n", solution.Year, solution.Day, part, result.Status, duration, details)
+		}
+
+		if recorded {
+			if err := answers.Write(path); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d part(s) do not match their recorded answer", failed)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jhh3/aoc/common"
+)
+
+func TestVerify(t *testing.T) {
+	// Both take minutes on the real input.
+	common.RunVerifyTests(t, "../../..", "y2023d05-part2", "y2024d16-part2")
+}
@@ -1,13 +1,40 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/jhh3/aoc/common"
+	"github.com/jhh3/aoc/skeleton"
 )
 
 func main() {
 	args := common.MustParseInputGetterFlags(os.Args[1:], false)
-	reader := common.NewProblemReader(args)
-	reader.MustGetInput()
+	if err := run(args); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args *common.InputGetterFlags) error {
+	reader, err := common.NewProblemReader(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := reader.GetInput(); err != nil {
+		return err
+	}
+	// The aoc command imports a day once it has an input.
+	if args.CacheDir == "" {
+		skeleton.WriteSolutionImports()
+	}
+
+	if args.Description {
+		if _, err := reader.GetDescription(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"time"
 
@@ -11,12 +12,13 @@ import (
 
 func main() {
 	args := mustParseArgs()
-	skeleton.Run(args.Day, args.Year)
+	skeleton.Run(args.Day, args.Year, fetchExamples(args))
 }
 
 type Args struct {
-	Year int
-	Day  int
+	Year   int
+	Day    int
+	Client common.InputGetterFlags
 }
 
 func parseArgs() (*Args, error) {
@@ -27,11 +29,15 @@ func parseArgs() (*Args, error) {
 	today := time.Now()
 	fs.IntVar(&parsedArgs.Year, "year", today.Year(), "The year of the puzzle to solve")
 	fs.IntVar(&parsedArgs.Day, "day", today.Day(), "The day of the puzzle to solve")
+	parsedArgs.Client.BindClientFlags(fs)
 
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		return nil, err
 	}
 
+	parsedArgs.Client.Year = parsedArgs.Year
+	parsedArgs.Client.Day = parsedArgs.Day
+
 	return &parsedArgs, nil
 }
 
@@ -40,3 +46,27 @@ func mustParseArgs() *Args {
 	common.CheckErr(err, "Failed to parse args")
 	return args
 }
+
+// fetchExamples pulls the examples from the puzzle page. The skeleton is
+// still made with placeholders when that is not possible.
+func fetchExamples(args *Args) []common.PuzzleExample {
+	reader, err := common.NewProblemReader(&args.Client)
+	if err != nil {
+		fmt.Println("\tNo session, skipping examples:", err)
+		return nil
+	}
+
+	page, err := reader.GetPuzzlePage()
+	if err != nil {
+		fmt.Println("\tFailed to get puzzle page, skipping examples:", err)
+		return nil
+	}
+
+	examples, err := common.ExtractExamples(page)
+	if err != nil {
+		fmt.Println("\tFailed to extract examples:", err)
+		return nil
+	}
+
+	return examples
+}
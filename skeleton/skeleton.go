@@ -7,15 +7,33 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"text/template"
+
+	"github.com/jhh3/aoc/common"
 )
 
 //go:embed templates/*.tmpl
 var fs embed.FS
 
-// Run makes a skeleton main.go and main_test.go file for the given day and year
-func Run(day, year int) {
+// templateData is what the templates are rendered with.
+type templateData struct {
+	Day    string
+	DayNum int
+	Year   int
+
+	// ExampleInput is the example of part 1, ExamplePart2Input is only set
+	// when part 2 comes with an example of its own.
+	ExampleInput      string
+	ExamplePart2Input string
+	Want              [2]string
+}
+
+// Run makes a skeleton main.go and main_test.go file for the given day and
+// year, filled in with the examples extracted from the puzzle page if any.
+func Run(day, year int, examples []common.PuzzleExample) {
 	validateInput(day, year)
+	data := newTemplateData(day, year, examples)
 
 	// Load the templates
 	ts, err := template.ParseFS(fs, "templates/*.tmpl")
@@ -31,31 +49,117 @@ func Run(day, year int) {
 	}
 
 	// Make each file
-	makeFile("main.go", day, year, ts)
-	makeFile("main_test.go", day, year, ts)
-	makeFile("example_input.txt", day, year, ts)
+	makeFile("main.go", data, ts)
+	makeFile("main_test.go", data, ts)
+	makeFile("example_input.txt", data, ts)
+	if data.ExamplePart2Input != "" {
+		makeFile("example_part2_input.txt", data, ts)
+	}
+
+	// The day builds with an empty input until make input fetches it, the
+	// aoc command only imports it from then on.
+	makeEmptyInput(year, day)
+	writeSolutionImports(ts)
 
 	fmt.Printf("templates made for %d-day%d\n", year, day)
 }
 
-func makeFile(filename string, day, year int, tmpl *template.Template) {
-	fullFn := filepath.Join(dirname(), "../", fmt.Sprintf("%d/day%02d/%s", year, day, filename))
+// WriteSolutionImports regenerates the list of day packages the aoc command
+// imports, to be called once the input of a day is fetched.
+func WriteSolutionImports() {
+	ts, err := template.ParseFS(fs, "templates/*.tmpl")
+	if err != nil {
+		log.Fatalf("parsing tmpls directory: %s", err)
+	}
+	writeSolutionImports(ts)
+}
+
+// writeSolutionImports regenerates the list of day packages the aoc command
+// imports, so every day on disk with an input registers its solver. Days
+// without one are left out, they would only fail when run.
+func writeSolutionImports(tmpl *template.Template) {
+	root := filepath.Join(dirname(), "../")
+	dirs, err := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]/day[0-9][0-9]"))
+	if err != nil {
+		log.Fatalf("listing day directories: %s", err)
+	}
+
+	packages := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if info, err := os.Stat(filepath.Join(dir, "input.txt")); err != nil || info.Size() == 0 {
+			continue
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			log.Fatalf("resolving %s: %s", dir, err)
+		}
+		packages = append(packages, filepath.ToSlash(rel))
+	}
+	sort.Strings(packages)
+
+	fn := filepath.Join(root, "scripts/cmd/aoc/solutions.go")
+	f, err := os.Create(fn)
+	if err != nil {
+		log.Fatalf("creating %s file: %v", fn, err)
+	}
+	defer f.Close()
+
+	if err := tmpl.ExecuteTemplate(f, "solutions.go.tmpl", packages); err != nil {
+		log.Fatalf("writing %s: %v", fn, err)
+	}
+}
+
+func newTemplateData(day, year int, examples []common.PuzzleExample) templateData {
+	data := templateData{
+		Day:          fmt.Sprintf("%02d", day),
+		DayNum:       day,
+		Year:         year,
+		ExampleInput: "test input\n",
+	}
+
+	for _, example := range examples {
+		if example.Part < 1 || example.Part > 2 {
+			continue
+		}
+		data.Want[example.Part-1] = example.Answer
+
+		switch {
+		case example.Input == "":
+		case example.Part == 1:
+			data.ExampleInput = example.Input
+		case example.Input != data.ExampleInput:
+			data.ExamplePart2Input = example.Input
+		}
+	}
+
+	return data
+}
+
+func makeFile(filename string, data templateData, tmpl *template.Template) {
+	fullFn := filepath.Join(dirname(), "../", fmt.Sprintf("%d/day%s/%s", data.Year, data.Day, filename))
 	ensureNotOverwriting(fullFn)
 
 	f, err := os.Create(fullFn)
 	if err != nil {
 		log.Fatalf("creating %s file: %v", filename, err)
 	}
+	defer f.Close()
 
-	data := struct {
-		Day  string
-		Year int
-	}{
-		Day:  fmt.Sprintf("%02d", day),
-		Year: year,
+	if err := tmpl.ExecuteTemplate(f, fmt.Sprintf("%s.tmpl", filename), data); err != nil {
+		log.Fatalf("writing %s file: %v", filename, err)
 	}
+}
 
-	tmpl.ExecuteTemplate(f, fmt.Sprintf("%s.tmpl", filename), data)
+// makeEmptyInput creates an empty input.txt for the day to embed, unless
+// the input was fetched already.
+func makeEmptyInput(year, day int) {
+	fn := filepath.Join(dirname(), "../", fmt.Sprintf("%d/day%02d/input.txt", year, day))
+	if _, err := os.Stat(fn); err == nil {
+		return
+	}
+	if err := os.WriteFile(fn, nil, 0o644); err != nil {
+		log.Fatalf("creating %s file: %v", fn, err)
+	}
 }
 
 func ensureNotOverwriting(filename string) {